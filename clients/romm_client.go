@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mortar/models"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -188,6 +190,10 @@ type RomMRom struct {
 }
 
 const RomsEndpoint = "/api/roms/"
+const PlatformsEndpoint = "/api/platforms"
+
+// SearchPageSize is how many results SearchRoms asks RomM for at a time.
+const SearchPageSize = 500
 
 func NewRomMClient(hostname string, port int, username string, password string) *RomMClient {
 	return &RomMClient{
@@ -211,65 +217,264 @@ func (c *RomMClient) buildRootURL() string {
 }
 
 func (c *RomMClient) ListDirectory(platformID string) (shared.Items, error) {
-	auth := c.Username + ":" + c.Password
-	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
+	roms, err := c.ListRoms(platformID)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []shared.Item
+	for _, rawItem := range roms {
+		items = append(items, rawItem.Item())
+	}
+
+	return items, nil
+}
+
+// Item converts a RomM ROM to the item the game lists work with.
+func (r RomMRom) Item() shared.Item {
+	return shared.Item{
+		Filename:     r.FsName,
+		FileSize:     strconv.Itoa(r.FsSizeBytes),
+		LastModified: r.UpdatedAt.String(),
+		RomID:        strconv.Itoa(r.ID),
+		ArtURL:       r.PathCoverSmall,
+	}
+}
+
+// Metadata is what RomM knows about the ROM for searching and sorting: the names it goes by, its matched title first,
+// its genres, release year and rating.
+func (r RomMRom) Metadata() models.GameMetadata {
+	var names []string
+	for _, name := range append(slices.Clone(r.AlternativeNames), r.IgdbMetadata.AlternativeNames...) {
+		if name != "" && name != r.Name && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	if r.Name != "" {
+		names = append([]string{r.Name}, names...)
+	}
+
+	genres := r.Genres
+	if len(genres) == 0 {
+		genres = r.IgdbMetadata.Genres
+	}
+
+	return models.GameMetadata{
+		AlternativeNames: names,
+		Genres:           genres,
+		ReleaseYear:      r.releaseYear(),
+		Rating:           r.rating(),
+	}
+}
+
+func (r RomMRom) rating() float64 {
+	if r.AverageRating > 0 {
+		return r.AverageRating
+	}
+
+	rating, _ := strconv.ParseFloat(r.IgdbMetadata.TotalRating, 64)
+	return rating
+}
+
+// releaseYear reads first_release_date, which RomM reports in milliseconds and IGDB in seconds.
+func (r RomMRom) releaseYear() int {
+	date := r.FirstReleaseDate
+	if date == 0 {
+		date = int64(r.IgdbMetadata.FirstReleaseDate)
+	}
 
+	if date <= 0 {
+		return 0
+	}
+
+	if date > 100_000_000_000 {
+		return time.UnixMilli(date).UTC().Year()
+	}
+
+	return time.Unix(date, 0).UTC().Year()
+}
+
+// SearchRoms runs RomM's own search across every platform on the server, reading every page of results.
+func (c *RomMClient) SearchRoms(term string) ([]RomMRom, error) {
+	var roms []RomMRom
+
+	for {
+		page, err := c.searchRomsPage(term, len(roms))
+		if err != nil {
+			return nil, err
+		}
+
+		roms = append(roms, page.Items...)
+
+		if len(page.Items) == 0 || len(roms) >= page.Total {
+			return roms, nil
+		}
+	}
+}
+
+func (c *RomMClient) searchRomsPage(term string, offset int) (RomMList, error) {
 	u, err := url.Parse(c.buildRootURL())
 	if err != nil {
-		return nil, fmt.Errorf("unable to parse rom endpoint URL for listing: %v", err)
+		return RomMList{}, fmt.Errorf("unable to parse rom endpoint URL for searching: %v", err)
 	}
 
 	u = u.JoinPath(RomsEndpoint)
 
 	params := url.Values{}
-	params.Add("platform_id", platformID)
-	params.Add("limit", "10000")
+	params.Add("search_term", term)
+	params.Add("limit", strconv.Itoa(SearchPageSize))
+	params.Add("offset", strconv.Itoa(offset))
 
 	u.RawQuery = params.Encode()
 
 	req, err := http.NewRequest("GET", u.String(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to build rom list request: %v", err)
+		return RomMList{}, fmt.Errorf("unable to build rom search request: %v", err)
 	}
 
-	req.Header.Add("Authorization", authHeader)
+	req.Header.Add("Authorization", c.authHeader())
 
-	client := &http.Client{}
+	client := &http.Client{Timeout: 30 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("unable to call roms list endpoint: %v", err)
+		return RomMList{}, fmt.Errorf("unable to call roms search endpoint: %v", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return RomMList{}, fmt.Errorf("roms search endpoint returned %s", resp.Status)
+	}
+
 	var rawItemsList RomMList
 	err = json.NewDecoder(resp.Body).Decode(&rawItemsList)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode roms list JSON: %w", err)
+		return RomMList{}, fmt.Errorf("failed to decode roms search JSON: %w", err)
 	}
 
-	var items []shared.Item
-	for _, rawItem := range rawItemsList.Items {
-		items = append(items, shared.Item{
-			Filename:     rawItem.FsName,
-			FileSize:     strconv.Itoa(rawItem.FsSizeBytes),
-			LastModified: rawItem.UpdatedAt.String(),
-			RomID:        strconv.Itoa(rawItem.ID),
-			ArtURL:       rawItem.PathCoverSmall,
-		})
+	return rawItemsList, nil
+}
+
+// ListRoms returns the raw RomM ROM entries for a platform, including their hashes and metadata.
+func (c *RomMClient) ListRoms(platformID string) ([]RomMRom, error) {
+	list, err := c.ListRomsWithIndex(platformID)
+	if err != nil {
+		return nil, err
 	}
 
-	return items, nil
+	return list.Items, nil
+}
+
+// ListRomsWithIndex is ListRoms with the rest of the response, like the char_index of where each letter starts.
+func (c *RomMClient) ListRomsWithIndex(platformID string) (RomMList, error) {
+	listURL, err := c.BuildRomsListURL(platformID)
+	if err != nil {
+		return RomMList{}, err
+	}
+
+	req, err := http.NewRequest("GET", listURL, nil)
+	if err != nil {
+		return RomMList{}, fmt.Errorf("unable to build rom list request: %v", err)
+	}
+
+	req.Header.Add("Authorization", c.authHeader())
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return RomMList{}, fmt.Errorf("unable to call roms list endpoint: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// An error page must not be read as an empty list and cached
+	if resp.StatusCode != http.StatusOK {
+		return RomMList{}, fmt.Errorf("roms list endpoint returned %s", resp.Status)
+	}
+
+	var rawItemsList RomMList
+	err = json.NewDecoder(resp.Body).Decode(&rawItemsList)
+	if err != nil {
+		return RomMList{}, fmt.Errorf("failed to decode roms list JSON: %w", err)
+	}
+
+	return rawItemsList, nil
+}
+
+// BuildRomsListURL is the endpoint ListRoms reads a platform's ROMs from.
+func (c *RomMClient) BuildRomsListURL(platformID string) (string, error) {
+	u, err := url.Parse(c.buildRootURL())
+	if err != nil {
+		return "", fmt.Errorf("unable to parse rom endpoint URL for listing: %v", err)
+	}
+
+	u = u.JoinPath(RomsEndpoint)
+
+	params := url.Values{}
+	params.Add("platform_id", platformID)
+	params.Add("limit", "10000")
+
+	u.RawQuery = params.Encode()
+
+	return u.String(), nil
+}
+
+// ListPlatforms returns every platform configured on the RomM server.
+func (c *RomMClient) ListPlatforms() ([]RomMPlatform, error) {
+	u, err := url.Parse(c.buildRootURL())
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse platforms endpoint URL: %v", err)
+	}
+
+	u = u.JoinPath(PlatformsEndpoint)
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build platforms request: %v", err)
+	}
+
+	req.Header.Add("Authorization", c.authHeader())
+
+	// This runs at startup, a host that doesn't answer must not hold up the launch
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to call platforms endpoint: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("platforms endpoint returned %s", resp.Status)
+	}
+
+	var platforms []RomMPlatform
+	err = json.NewDecoder(resp.Body).Decode(&platforms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode platforms JSON: %w", err)
+	}
+
+	return platforms, nil
 }
 
 func (c *RomMClient) BuildDownloadURL(remotePath, filename string) (string, error) {
 	return url.JoinPath(c.buildRootURL(), RomsEndpoint, remotePath, "content", filename)
 }
 
+func (c *RomMClient) BuildArtURL(artPath string) (string, error) {
+	return url.JoinPath(c.buildRootURL(), artPath)
+}
+
+// BuildDownloadHeaders are the headers RomM needs to serve ROMs and covers.
 func (c *RomMClient) BuildDownloadHeaders() map[string]string {
 	headers := make(map[string]string)
+	headers["Authorization"] = c.authHeader()
 	return headers
 }
 
+func (c *RomMClient) authHeader() string {
+	auth := c.Username + ":" + c.Password
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
+}
+
 func (c *RomMClient) DownloadArt(remotePath, localPath, filename, rename string) (savedPath string, error error) {
 	logger := gaba.GetLoggerInstance()
 
@@ -0,0 +1,9 @@
+package models
+
+// ArcadeFilters hide sets from an arcade platform's game list. They only apply when the platform has a DAT file.
+type ArcadeFilters struct {
+	HideClones     bool `yaml:"hide_clones,omitempty" json:"hide_clones,omitempty"`
+	HideBootlegs   bool `yaml:"hide_bootlegs,omitempty" json:"hide_bootlegs,omitempty"`
+	HideBios       bool `yaml:"hide_bios,omitempty" json:"hide_bios,omitempty"`
+	HideMechanical bool `yaml:"hide_mechanical,omitempty" json:"hide_mechanical,omitempty"`
+}
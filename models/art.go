@@ -0,0 +1,31 @@
+package models
+
+type ArtProviderConfig struct {
+	Type     string `yaml:"type,omitempty" json:"type,omitempty"`
+	ArtType  string `yaml:"art_type,omitempty" json:"art_type,omitempty"`
+	Variant  string `yaml:"variant,omitempty" json:"variant,omitempty"`
+	Path     string `yaml:"path,omitempty" json:"path,omitempty"`
+	URL      string `yaml:"url,omitempty" json:"url,omitempty"`
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password string `yaml:"password,omitempty" json:"password,omitempty"`
+	SystemID string `yaml:"system_id,omitempty" json:"system_id,omitempty"`
+	Media    string `yaml:"media,omitempty" json:"media,omitempty"`
+}
+
+type ArtProviderConfigs []ArtProviderConfig
+
+// ArtProcessing is how downloaded art is sized and saved. Zero values fall back to the media layout's defaults.
+type ArtProcessing struct {
+	Width     int           `yaml:"width,omitempty" json:"width,omitempty"`
+	Height    int           `yaml:"height,omitempty" json:"height,omitempty"`
+	Mode      string        `yaml:"mode,omitempty" json:"mode,omitempty"`
+	Format    string        `yaml:"format,omitempty" json:"format,omitempty"`
+	Composite *ArtComposite `yaml:"composite,omitempty" json:"composite,omitempty"`
+}
+
+// ArtComposite places the downloaded art over a second piece of art, e.g. box art over a screenshot.
+type ArtComposite struct {
+	Background string  `yaml:"background,omitempty" json:"background,omitempty"`
+	Position   string  `yaml:"position,omitempty" json:"position,omitempty"`
+	Scale      float64 `yaml:"scale,omitempty" json:"scale,omitempty"`
+}
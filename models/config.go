@@ -13,5 +13,8 @@ type Config struct {
 	DownloadArt        bool                            `yaml:"download_art,omitempty" json:"download_art,omitempty"`
 	GroupBinCue        bool                            `yaml:"group_bin_cue,omitempty" json:"group_bin_cue,omitempty"`
 	GroupMultiDisc     bool                            `yaml:"group_multi_disc,omitempty" json:"group_multi_disc,omitempty"`
+	MediaLayout        string                          `yaml:"media_layout,omitempty" json:"media_layout,omitempty"`
+	ArtProcessing      *ArtProcessing                  `yaml:"art_processing,omitempty" json:"art_processing,omitempty"`
+	CatalogCacheTTL    string                          `yaml:"catalog_cache_ttl,omitempty" json:"catalog_cache_ttl,omitempty"`
 	LogLevel           string                          `yaml:"log_level,omitempty" json:"log_level,omitempty"`
 }
@@ -0,0 +1,85 @@
+package models
+
+import "strings"
+
+type Dat struct {
+	Name        string
+	Description string
+	Games       []DatGame
+}
+
+type DatGame struct {
+	Name         string
+	Description  string
+	Year         string
+	Manufacturer string
+	CloneOf      string
+	RomOf        string
+	IsBios       bool
+	IsDevice     bool
+	IsMechanical bool
+	DeviceRefs   []string
+	Roms         []DatRom
+}
+
+func (g DatGame) IsClone() bool {
+	return g.CloneOf != ""
+}
+
+func (g DatGame) IsBootleg() bool {
+	return strings.Contains(strings.ToLower(g.Manufacturer), "bootleg") ||
+		strings.Contains(strings.ToLower(g.Description), "(bootleg")
+}
+
+// NeedsParent is true for clones in split sets, where the ROMs shared with the parent only live in the parent's zip.
+func (g DatGame) NeedsParent() bool {
+	if !g.IsClone() {
+		return false
+	}
+
+	for _, rom := range g.Roms {
+		if rom.Merge != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+type DatRom struct {
+	Name   string
+	Size   int64
+	CRC    string
+	MD5    string
+	SHA1   string
+	Status string
+	Merge  string
+}
+
+func (r DatRom) IsBadDump() bool {
+	return r.Status == "baddump"
+}
+
+type VerifiedRom struct {
+	LocalPath    string
+	Game         DatGame
+	ExpectedName string
+}
+
+// VerificationReport sorts a platform's ROMs by how they compare to its DAT. HashMismatches are ROMs whose hashes match
+// nothing in the DAT but whose name does, which may be a bad dump, a hack or a different revision.
+type VerificationReport struct {
+	Verified       []VerifiedRom
+	BadDumps       []VerifiedRom
+	Misnamed       []VerifiedRom
+	HashMismatches []VerifiedRom
+	Unknown        []string
+	Missing        []DatGame
+}
+
+type RenameProposal struct {
+	LocalPath    string
+	CurrentName  string
+	ProposedName string
+	Source       string
+}
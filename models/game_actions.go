@@ -0,0 +1,16 @@
+package models
+
+import "qlova.tech/sum"
+
+type GameAction struct {
+	Search,
+	ToggleWishlist,
+	JumpToLetter,
+	Sort,
+	SaveSearch,
+	SmartFilters,
+	FilterPresets,
+	Refresh sum.Int[GameAction]
+}
+
+var GameActions = sum.Int[GameAction]{}.Sum()
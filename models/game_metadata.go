@@ -0,0 +1,12 @@
+package models
+
+// GameMetadata is what a host knows about a game beyond its file, currently only RomM provides it.
+type GameMetadata struct {
+	AlternativeNames []string `json:"alternative_names,omitempty"`
+	Genres           []string `json:"genres,omitempty"`
+	ReleaseYear      int      `json:"release_year,omitempty"`
+	Rating           float64  `json:"rating,omitempty"`
+}
+
+// GameMetadataIndex maps a RomM ROM ID to its metadata.
+type GameMetadataIndex map[string]GameMetadata
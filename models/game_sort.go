@@ -0,0 +1,12 @@
+package models
+
+// GameSort is how a platform's games list is ordered. By is one of name, size, modified, rating or year, empty
+// meaning name.
+type GameSort struct {
+	By         string `yaml:"by,omitempty" json:"by,omitempty"`
+	Descending bool   `yaml:"descending,omitempty" json:"descending,omitempty"`
+}
+
+func (s GameSort) IsDefault() bool {
+	return (s.By == "" || s.By == "name") && !s.Descending
+}
@@ -17,6 +17,8 @@ type Host struct {
 	Platforms Platforms `yaml:"platforms,omitempty" json:"platforms,omitempty"`
 	Filters   Filters   `yaml:"filters,omitempty" json:"filters,omitempty"`
 
+	ArtProviders ArtProviderConfigs `yaml:"art_providers,omitempty" json:"art_providers,omitempty"`
+
 	TableColumns       shared.TableColumns `yaml:"-" json:"-"`
 	SourceReplacements SourceReplacements  `yaml:"-" json:"-"`
 }
@@ -27,9 +29,57 @@ func (h Host) Value() interface{} {
 
 type Hosts []Host
 
+// Filters decide which games a host or platform lists. Presets are named rule sets that can be switched on and off
+// from the games list.
 type Filters struct {
-	InclusiveFilters []string `yaml:"inclusive_filters,omitempty" json:"inclusive_filters,omitempty"`
-	ExclusiveFilters []string `yaml:"exclusive_filters,omitempty" json:"exclusive_filters,omitempty"`
+	FilterRules `yaml:",inline"`
+
+	Presets FilterPresets `yaml:"presets,omitempty" json:"presets,omitempty"`
+}
+
+// FilterRules are matched against a game's name. Substring and pattern rules look at the whole name, tag rules at
+// each of its No-Intro tags. Patterns and tags are case-insensitive regular expressions.
+type FilterRules struct {
+	InclusiveFilters  []string `yaml:"inclusive_filters,omitempty" json:"inclusive_filters,omitempty"`
+	ExclusiveFilters  []string `yaml:"exclusive_filters,omitempty" json:"exclusive_filters,omitempty"`
+	InclusivePatterns []string `yaml:"inclusive_patterns,omitempty" json:"inclusive_patterns,omitempty"`
+	ExclusivePatterns []string `yaml:"exclusive_patterns,omitempty" json:"exclusive_patterns,omitempty"`
+	IncludeTags       []string `yaml:"include_tags,omitempty" json:"include_tags,omitempty"`
+	ExcludeTags       []string `yaml:"exclude_tags,omitempty" json:"exclude_tags,omitempty"`
+}
+
+type FilterPreset struct {
+	Name        string `yaml:"name,omitempty" json:"name,omitempty"`
+	Enabled     bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	FilterRules `yaml:",inline"`
+}
+
+type FilterPresets []FilterPreset
+
+func (r FilterRules) HasInclusions() bool {
+	return len(r.InclusiveFilters) > 0 || len(r.InclusivePatterns) > 0 || len(r.IncludeTags) > 0
+}
+
+func (r FilterRules) HasExclusions() bool {
+	return len(r.ExclusiveFilters) > 0 || len(r.ExclusivePatterns) > 0 || len(r.ExcludeTags) > 0
+}
+
+// WithoutInclusions keeps only the exclusive rules.
+func (r FilterRules) WithoutInclusions() FilterRules {
+	return FilterRules{
+		ExclusiveFilters:  r.ExclusiveFilters,
+		ExclusivePatterns: r.ExclusivePatterns,
+		ExcludeTags:       r.ExcludeTags,
+	}
+}
+
+// WithoutExclusions keeps only the inclusive rules.
+func (r FilterRules) WithoutExclusions() FilterRules {
+	return FilterRules{
+		InclusiveFilters:  r.InclusiveFilters,
+		InclusivePatterns: r.InclusivePatterns,
+		IncludeTags:       r.IncludeTags,
+	}
 }
 
 type SourceReplacements map[string]string
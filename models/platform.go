@@ -6,10 +6,22 @@ type Platform struct {
 	LocalDirectory   string `yaml:"local_directory,omitempty" json:"local_directory,omitempty"`
 	HostSubdirectory string `yaml:"host_subdirectory,omitempty" json:"host_subdirectory,omitempty"`
 	RomMPlatformID   string `yaml:"romm_platform_id,omitempty" json:"romm_platform_id,omitempty"`
+	DatFile          string `yaml:"dat_file,omitempty" json:"dat_file,omitempty"`
+	MediaSystem      string `yaml:"media_system,omitempty" json:"media_system,omitempty"`
 
-	SkipExclusiveFilters bool `yaml:"skip_exclusive_filters,omitempty" json:"skip_exclusive_filters,omitempty"`
-	SkipInclusiveFilters bool `yaml:"skip_inclusive_filters,omitempty" json:"skip_inclusive_filters,omitempty"`
-	IsArcade             bool `yaml:"is_arcade,omitempty" json:"is_arcade,omitempty"`
+	Filters              Filters         `yaml:"filters,omitempty" json:"filters,omitempty"`
+	FilterPresets        map[string]bool `yaml:"filter_presets,omitempty" json:"filter_presets,omitempty"`
+	SkipExclusiveFilters bool            `yaml:"skip_exclusive_filters,omitempty" json:"skip_exclusive_filters,omitempty"`
+	SkipInclusiveFilters bool            `yaml:"skip_inclusive_filters,omitempty" json:"skip_inclusive_filters,omitempty"`
+	IsArcade             bool            `yaml:"is_arcade,omitempty" json:"is_arcade,omitempty"`
+
+	ArcadeRomset  string        `yaml:"arcade_romset,omitempty" json:"arcade_romset,omitempty"`
+	ArcadeFilters ArcadeFilters `yaml:"arcade_filters,omitempty" json:"arcade_filters,omitempty"`
+
+	ArtProviders ArtProviderConfigs `yaml:"art_providers,omitempty" json:"art_providers,omitempty"`
+
+	SavedQueries SavedQueries `yaml:"saved_queries,omitempty" json:"saved_queries,omitempty"`
+	Sort         GameSort     `yaml:"sort,omitempty" json:"sort,omitempty"`
 
 	Host Host `yaml:"-" json:"-"`
 }
@@ -7,9 +7,21 @@ type ScreenName struct {
 	Settings,
 	PlatformSelection,
 	GameList,
+	GameActions,
+	SmartFilters,
+	FilterPresets,
+	SortGames,
+	LetterJump,
 	SearchBox,
+	GlobalSearch,
+	GlobalDownload,
+	Wishlist,
 	Download,
-	DownloadArt sum.Int[ScreenName]
+	DownloadArt,
+	Tools,
+	VerifyCollection,
+	IdentifyRoms,
+	ScrapeArt sum.Int[ScreenName]
 }
 
 var ScreenNames = sum.Int[ScreenName]{}.Sum()
@@ -0,0 +1,28 @@
+package models
+
+import shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+
+// SearchResult is a game found by the global search along with the platform it belongs to.
+type SearchResult struct {
+	Platform Platform
+	Item     shared.Item
+}
+
+type SearchResults []SearchResult
+
+// SearchSelection is what the global search and the wishlist hand to the download screen. Query and Results are kept
+// so the results can be shown again afterwards.
+type SearchSelection struct {
+	Query        string
+	Results      SearchResults
+	Selected     SearchResults
+	FromWishlist bool
+}
+
+// SavedQuery is a search kept on a platform so it can be applied again as a smart filter.
+type SavedQuery struct {
+	Name  string `yaml:"name,omitempty" json:"name,omitempty"`
+	Query string `yaml:"query,omitempty" json:"query,omitempty"`
+}
+
+type SavedQueries []SavedQuery
@@ -0,0 +1,16 @@
+package models
+
+import "qlova.tech/sum"
+
+type Tool struct {
+	VerifyCollection,
+	IdentifyRoms,
+	ScrapeArt sum.Int[Tool]
+}
+
+var Tools = sum.Int[Tool]{}.Sum()
+
+type ToolSelection struct {
+	Tool     sum.Int[Tool]
+	Platform Platform
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+)
+
+// WishlistEntry is a starred game, kept by host and platform name so it survives config edits that don't rename them.
+type WishlistEntry struct {
+	Host     string      `json:"host"`
+	Platform string      `json:"platform"`
+	Item     shared.Item `json:"item"`
+	AddedAt  time.Time   `json:"added_at"`
+}
+
+type Wishlist []WishlistEntry
@@ -8,14 +8,13 @@ import (
 	"mortar/utils"
 	"mortar/web"
 	"os"
-	"path/filepath"
-	"strings"
 
 	_ "github.com/UncleJunVIP/certifiable"
 	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
 	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
 	"github.com/UncleJunVIP/nextui-pak-shared-functions/filebrowser"
 	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"qlova.tech/sum"
 )
 
 func init() {
@@ -27,14 +26,6 @@ func init() {
 
 	common.InitIncludes()
 
-	if !utils.IsConnectedToInternet() {
-		_, err := gaba.ConfirmationMessage("No Internet Connection!\nMake sure you are connected to Wi-Fi.", []gaba.FooterHelpItem{
-			{ButtonName: "B", HelpText: "Quit"},
-		}, gaba.MessageOptions{})
-		defer cleanup()
-		common.LogStandardFatal("No Internet Connection", err)
-	}
-
 	config, err := utils.LoadConfig()
 	if err != nil {
 		web.QRScreen("Continue")
@@ -70,6 +61,14 @@ func init() {
 		config.ArtDownloadType = shared.ArtDownloadTypes.BOX_ART
 	}
 
+	if err := utils.LoadSystemsMapping(); err != nil {
+		logger.Error("Unable to load systems mapping", "error", err)
+	}
+
+	utils.SetMediaLayout(config.MediaLayout)
+	utils.SetArtProcessing(config.ArtProcessing)
+	utils.SetCatalogCacheTTL(config.CatalogCacheTTL)
+
 	fb := filebrowser.NewFileBrowser(logger)
 	err = fb.CWD(utils.GetRomDirectory(), false)
 	if err != nil {
@@ -88,24 +87,33 @@ func init() {
 
 	for hostIdx, host := range config.Hosts {
 		for sectionIdx, section := range host.Platforms {
-			if section.SystemTag != "" {
-				config.Hosts[hostIdx].Platforms[sectionIdx].LocalDirectory = romDirectories[section.SystemTag]
+			if directory, ok := utils.MatchLocalDirectory(section.SystemTag, romDirectories); ok {
+				config.Hosts[hostIdx].Platforms[sectionIdx].LocalDirectory = directory
 			}
 		}
 	}
 
-	missingPlatforms := utils.AllPlatformsHaveLocalFolders(config)
+	_, _ = gaba.ProcessMessage("Checking hosts...", gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+		utils.ProbeHosts(config.Hosts)
+		return nil, nil
+	})
+
+	for _, host := range config.Hosts {
+		if !utils.IsHostReachable(host) {
+			logger.Info("Host is unreachable", "host", host.DisplayName, "error", utils.HostProbeError(host))
+		}
+	}
+
+	utils.ResolveRomMPlatformIDs(config)
+
+	missingPlatforms := utils.PlatformsWithoutLocalFolders(config)
 
 	if len(missingPlatforms) > 0 {
-		mps := strings.Join(missingPlatforms, "\n")
-		gaba.ConfirmationMessage(fmt.Sprintf("These platforms are missing local folders:\n%s", mps), []gaba.FooterHelpItem{
-			{ButtonName: "B", HelpText: "Quit"},
-		}, gaba.MessageOptions{})
-		logger.Error("Not all platforms have local folders. Ensure they are correct and try again. "+
+		logger.Info("Not all platforms have local folders. "+
 			"If you are using the automation folder detection feature, please make sure a matching system tag exits on a directory in your ROM directory.",
-			"missing_platforms", missingPlatforms)
+			"missing_platforms", len(missingPlatforms))
 
-		os.Exit(1)
+		ui.ResolveMissingFolders(config, missingPlatforms, fb.Items)
 	}
 
 	logger.Debug("Populated ROM Directories by System Tag", "config", config)
@@ -125,6 +133,8 @@ func main() {
 
 	logger.Debug("Starting Mortar")
 
+	ui.StartPrefetch(appState.Config.Hosts)
+
 	var screen models.Screen
 
 	quitOnBack := len(appState.Config.Hosts) == 1
@@ -143,14 +153,22 @@ func main() {
 			switch code {
 			case 0:
 				host := res.(models.Host)
-				screen = ui.InitPlatformSelection(host, quitOnBack)
+				if ui.ConfirmHostReachable(host) {
+					screen = ui.InitPlatformSelection(host, quitOnBack)
+				}
 			case 4:
 				screen = ui.InitSettingsScreen()
+			case 5:
+				screen = ui.InitGlobalSearchScreen("", nil)
+			case 6:
+				screen = ui.InitWishlistScreen()
 			case 1, 2:
 				os.Exit(0)
 			}
 		case ui.Screens.Settings:
-			if code != 404 {
+			if code == 5 {
+				screen = ui.InitToolsScreen()
+			} else if code != 404 {
 				if len(appState.Config.Hosts) == 1 {
 					screen = ui.InitPlatformSelection(appState.Config.Hosts[0], quitOnBack)
 				} else {
@@ -170,6 +188,10 @@ func main() {
 				screen = ui.InitMainMenu(appState.Config.Hosts)
 			case 4:
 				screen = ui.InitSettingsScreen()
+			case 5:
+				screen = ui.InitGlobalSearchScreen("", nil)
+			case 6:
+				screen = ui.InitWishlistScreen()
 			case 404:
 				screen = ui.InitMainMenu(appState.Config.Hosts)
 			case -1:
@@ -190,6 +212,9 @@ func main() {
 				}
 
 			case 4:
+				screen = ui.InitGameActionsScreen(gl.Platform, gl.SearchFilter, res.(ui.GameListFocus))
+
+			case 5:
 				screen = ui.InitSearch(gl.Platform, gl.SearchFilter)
 
 			case 404:
@@ -199,6 +224,71 @@ func main() {
 					screen = ui.InitPlatformSelection(gl.Platform.Host, quitOnBack)
 				}
 			}
+		case ui.Screens.GameActions:
+			ga := screen.(ui.GameActionsScreen)
+			switch code {
+			case 0:
+				switch res.(sum.Int[models.GameAction]) {
+				case models.GameActions.Search:
+					screen = ui.InitSearch(ga.Platform, ga.SearchFilter)
+				case models.GameActions.SaveSearch:
+					platform := ui.SaveSearch(ga.Platform, ga.SearchFilter)
+					screen = ui.InitGamesList(platform, state.GetAppState().CurrentFullGamesList, ga.SearchFilter)
+				case models.GameActions.SmartFilters:
+					screen = ui.InitSmartFiltersScreen(ga.Platform, ga.SearchFilter)
+				case models.GameActions.FilterPresets:
+					screen = ui.InitFilterPresetsScreen(ga.Platform, ga.SearchFilter)
+				case models.GameActions.Sort:
+					screen = ui.InitSortGamesScreen(ga.Platform, ga.SearchFilter)
+				case models.GameActions.ToggleWishlist:
+					ui.ToggleWishlist(ga.Platform, ga.Focus.Games)
+					screen = ui.InitGamesList(ga.Platform, state.GetAppState().CurrentFullGamesList, ga.SearchFilter)
+				case models.GameActions.JumpToLetter:
+					screen = ui.InitLetterJumpScreen(ga.Platform, ga.SearchFilter, ga.Focus.Letters)
+				case models.GameActions.Refresh:
+					screen = ui.RefreshGamesList(ga.Platform, ga.SearchFilter)
+				}
+			default:
+				screen = ui.InitGamesList(ga.Platform, state.GetAppState().CurrentFullGamesList, ga.SearchFilter)
+			}
+		case ui.Screens.SmartFilters:
+			sf := screen.(ui.SmartFiltersScreen)
+			switch code {
+			case 0:
+				state.SetLastSelectedPosition(0, 0)
+				screen = ui.InitGamesList(sf.Platform, state.GetAppState().CurrentFullGamesList, res.(models.SavedQuery).Query)
+			case 4, 404:
+				platform := res.(models.Platform)
+				if len(platform.SavedQueries) > 0 {
+					screen = ui.InitSmartFiltersScreen(platform, sf.SearchFilter)
+				} else {
+					screen = ui.InitGamesList(platform, state.GetAppState().CurrentFullGamesList, sf.SearchFilter)
+				}
+			default:
+				screen = ui.InitGamesList(sf.Platform, state.GetAppState().CurrentFullGamesList, sf.SearchFilter)
+			}
+		case ui.Screens.FilterPresets:
+			fp := screen.(ui.FilterPresetsScreen)
+			platform := fp.Platform
+			if code == 0 {
+				platform = res.(models.Platform)
+				state.SetLastSelectedPosition(0, 0)
+			}
+			screen = ui.InitGamesList(platform, state.GetAppState().CurrentFullGamesList, fp.SearchFilter)
+		case ui.Screens.SortGames:
+			sg := screen.(ui.SortGamesScreen)
+			platform := sg.Platform
+			if code == 0 {
+				platform = res.(models.Platform)
+				state.SetLastSelectedPosition(0, 0)
+			}
+			screen = ui.InitGamesList(platform, state.GetAppState().CurrentFullGamesList, sg.SearchFilter)
+		case ui.Screens.LetterJump:
+			lj := screen.(ui.LetterJumpScreen)
+			if code == 0 {
+				state.SetLastSelectedPosition(res.(int), 0)
+			}
+			screen = ui.InitGamesList(lj.Platform, state.GetAppState().CurrentFullGamesList, lj.SearchFilter)
 		case ui.Screens.SearchBox:
 			sb := screen.(ui.Search)
 			switch code {
@@ -209,62 +299,57 @@ func main() {
 			default:
 				screen = ui.InitGamesList(sb.Platform, state.GetAppState().CurrentFullGamesList, "")
 			}
+		case ui.Screens.GlobalSearch:
+			switch code {
+			case 0:
+				screen = ui.InitGlobalDownloadScreen(res.(models.SearchSelection))
+			case 4, 404:
+				screen = ui.InitGlobalSearchScreen(res.(models.SearchSelection).Query, nil)
+			default:
+				if quitOnBack {
+					screen = ui.InitPlatformSelection(appState.Config.Hosts[0], quitOnBack)
+				} else {
+					screen = ui.InitMainMenu(appState.Config.Hosts)
+				}
+			}
+		case ui.Screens.GlobalDownload:
+			gd := screen.(ui.GlobalDownloadScreen)
+			if gd.Selection.FromWishlist {
+				if code == 0 {
+					ui.RemoveDownloadedFromWishlist(res.(models.SearchResults))
+				}
+				screen = ui.InitWishlistScreen()
+			} else {
+				screen = ui.InitGlobalSearchScreen(gd.Selection.Query, gd.Selection.Results)
+			}
+		case ui.Screens.Wishlist:
+			if code == 0 {
+				screen = ui.InitGlobalDownloadScreen(res.(models.SearchSelection))
+			} else if quitOnBack {
+				screen = ui.InitPlatformSelection(appState.Config.Hosts[0], quitOnBack)
+			} else {
+				screen = ui.InitMainMenu(appState.Config.Hosts)
+			}
 		case ui.Screens.Download:
 			ds := screen.(ui.DownloadScreen)
 			switch code {
 			case 0:
 				downloadedGames := res.([]shared.Item)
 
+				var downloaded models.SearchResults
+				for _, game := range downloadedGames {
+					downloaded = append(downloaded, models.SearchResult{Platform: ds.Platform, Item: game})
+				}
+				ui.RemoveDownloadedFromWishlist(downloaded)
+
+				// Art is named after what the frontend launches once the download has been unzipped or grouped
+				var launchableGames shared.Items
 				for _, game := range downloadedGames {
-					isMultiDisc := utils.IsMultiDisc(ds.Platform, game)
-
-					if filepath.Ext(game.Filename) == ".zip" && !screen.(ui.DownloadScreen).Platform.IsArcade {
-						isBinCue := utils.HasBinCue(ds.Platform, game)
-
-						if isMultiDisc && appState.Config.GroupMultiDisc {
-							utils.GroupMultiDisk(ds.Platform, game)
-						} else if appState.Config.GroupBinCue && isBinCue {
-							utils.GroupBinCue(ds.Platform, game)
-						} else if appState.Config.UnzipDownloads {
-							utils.UnzipGame(ds.Platform, game)
-						}
-					} else if appState.Config.GroupMultiDisc && isMultiDisc {
-						utils.GroupMultiDisk(ds.Platform, game)
-					}
+					launchableGames = append(launchableGames, utils.PostProcessDownload(ds.Platform, game, appState.Config)...)
 				}
 
 				if appState.Config.DownloadArt {
-					seenBaseNames := make(map[string]bool)
-
-					// Create a pruned list for art downloads that only includes one instance of each multi-disk game
-					prunedGamesForArt := make([]shared.Item, 0, len(downloadedGames))
-
-					for _, game := range downloadedGames {
-						// Get base name by trimming at "(Disk" or "(Disc"
-						baseName := game.DisplayName
-						diskIndex := strings.Index(baseName, "(Disk")
-						discIndex := strings.Index(baseName, "(Disc")
-
-						trimIndex := -1
-						if diskIndex != -1 && discIndex != -1 {
-							trimIndex = min(diskIndex, discIndex)
-						} else if diskIndex != -1 {
-							trimIndex = diskIndex
-						} else if discIndex != -1 {
-							trimIndex = discIndex
-						}
-
-						if trimIndex != -1 {
-							baseName = baseName[:trimIndex]
-						}
-						baseName = strings.TrimSpace(baseName)
-
-						// If we haven't seen this base name before, add it to the pruned list
-						if !seenBaseNames[baseName] {
-							seenBaseNames[baseName] = true
-							prunedGamesForArt = append(prunedGamesForArt, game)
-						}
-					}
+					prunedGamesForArt := utils.UniqueArtGames(launchableGames)
 
 					screen = ui.InitDownloadArtScreen(ds.Platform, prunedGamesForArt, appState.Config.ArtDownloadType, ds.SearchFilter)
 				} else {
@@ -278,6 +363,34 @@ func main() {
 		case ui.Screens.DownloadArt:
 			da := screen.(ui.DownloadArtScreen)
 			screen = ui.InitGamesList(da.Platform, state.GetAppState().CurrentFullGamesList, da.SearchFilter)
+		case ui.Screens.Tools:
+			switch code {
+			case 0:
+				selection := res.(models.ToolSelection)
+				switch selection.Tool {
+				case models.Tools.VerifyCollection:
+					screen = ui.InitVerifyCollectionScreen(selection.Platform)
+				case models.Tools.IdentifyRoms:
+					screen = ui.InitIdentifyRomsScreen(selection.Platform)
+				case models.Tools.ScrapeArt:
+					screen = ui.InitScrapeArtScreen(selection.Platform)
+				}
+			default:
+				screen = ui.InitSettingsScreen()
+			}
+		case ui.Screens.VerifyCollection:
+			vc := screen.(ui.VerifyCollectionScreen)
+			switch code {
+			case 0:
+				games := res.(shared.Items)
+				// The games list shown after the download has to be this platform's, not the last one browsed
+				state.SetCurrentFullGamesList(nil)
+				screen = ui.InitDownloadScreen(vc.Platform, games, games, "")
+			default:
+				screen = ui.InitToolsScreen()
+			}
+		case ui.Screens.IdentifyRoms, ui.Screens.ScrapeArt:
+			screen = ui.InitToolsScreen()
 		}
 	}
 }
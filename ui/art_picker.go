@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"fmt"
+	"mortar/models"
+	"mortar/utils"
+	"os"
+	"path/filepath"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"qlova.tech/sum"
+)
+
+// pickArt lists the top candidates from every art provider and type for a game. Opening one previews it, and the
+// previews of the ones after it follow before one is saved. The search can be repeated with custom text. It returns the saved art or an empty string when the user backs out without choosing any.
+func pickArt(platform models.Platform, game shared.Item, downloadType sum.Int[shared.ArtDownloadType]) string {
+	search := game
+	selectedIndex := 0
+
+	previews := make(map[string]string)
+	defer func() {
+		for _, previewPath := range previews {
+			if previewPath != "" {
+				common.DeleteFile(previewPath)
+			}
+		}
+	}()
+
+	for {
+		process, _ := gaba.ProcessMessage(fmt.Sprintf("Finding art for %s...", search.DisplayName), gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+			return utils.FindArtCandidates(platform, search, downloadType, utils.DefaultArtCandidateLimit), nil
+		})
+
+		candidates, _ := process.Result.([]utils.ArtCandidate)
+
+		var menuItems []gaba.MenuItem
+		for _, candidate := range candidates {
+			menuItems = append(menuItems, gaba.MenuItem{
+				Text:     fmt.Sprintf("%s | %s", candidate.Provider, candidate.Filename),
+				Selected: false,
+				Focused:  false,
+				Metadata: candidate,
+			})
+		}
+
+		if len(menuItems) == 0 {
+			searchAgain, err := gaba.ConfirmationMessage(fmt.Sprintf("No art found for\n%s", search.DisplayName), []gaba.FooterHelpItem{
+				{ButtonName: "B", HelpText: "No Art"},
+				{ButtonName: "A", HelpText: "Search"},
+			}, gaba.MessageOptions{})
+			if err != nil || searchAgain.IsNone() {
+				return ""
+			}
+
+			if text, ok := artSearchText(search.DisplayName); ok {
+				search.DisplayName = text
+			}
+			continue
+		}
+
+		options := gaba.DefaultListOptions(game.DisplayName, menuItems)
+		options.EnableAction = true
+		options.SelectedIndex = min(selectedIndex, len(menuItems)-1)
+		options.FooterHelpItems = []gaba.FooterHelpItem{
+			{ButtonName: "B", HelpText: "No Art"},
+			{ButtonName: "X", HelpText: "Search"},
+			{ButtonName: "A", HelpText: "Preview"},
+		}
+
+		selection, err := gaba.List(options)
+		if err != nil || selection.IsNone() {
+			return ""
+		}
+
+		if selection.Unwrap().ActionTriggered {
+			if text, ok := artSearchText(search.DisplayName); ok {
+				search.DisplayName = text
+				selectedIndex = 0
+			}
+			continue
+		}
+
+		if selection.Unwrap().SelectedIndex == -1 {
+			return ""
+		}
+
+		selectedIndex = selection.Unwrap().SelectedIndex
+
+		artPath, index := previewArt(platform, game, candidates, selectedIndex, previews)
+		selectedIndex = index
+
+		if artPath != "" {
+			return artPath
+		}
+	}
+}
+
+// previewArt shows the candidate at index and B moves on to the preview of the next one, back to the list after the
+// last. Previews are kept in previews so coming back to one doesn't download it again. It returns the saved art, or an
+// empty string and the candidate that was showing when the user goes back to the list.
+func previewArt(platform models.Platform, game shared.Item, candidates []utils.ArtCandidate, index int, previews map[string]string) (string, int) {
+	logger := gaba.GetLoggerInstance()
+
+	for {
+		candidate := candidates[index]
+		title := fmt.Sprintf("%s (%d/%d)", candidate.Provider, index+1, len(candidates))
+
+		previewPath, ok := previews[candidate.URL+candidate.SourcePath]
+		if !ok {
+			previewPath = filepath.Join(utils.CacheDirectory(), fmt.Sprintf("art_preview_%d%s", len(previews), utils.ArtExtension(utils.ActiveArtProcessing())))
+
+			preview := utils.ResolveCandidate(platform, game, candidate)
+			preview.Location = previewPath
+
+			var fetchErr error
+			_, _ = gaba.ProcessMessage("Loading preview...", gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+				_, fetchErr = utils.FetchArt(platform, preview)
+				return nil, nil
+			})
+			if fetchErr != nil {
+				logger.Error("Unable to fetch art preview", "game", game.DisplayName, "candidate", candidate.Filename, "error", fetchErr)
+				previewPath = ""
+			}
+
+			previews[candidate.URL+candidate.SourcePath] = previewPath
+		}
+
+		last := index == len(candidates)-1
+
+		footer := []gaba.FooterHelpItem{{ButtonName: "B", HelpText: "Next"}}
+		if last {
+			footer[0].HelpText = "Back"
+		}
+		if previewPath != "" {
+			footer = append(footer, gaba.FooterHelpItem{ButtonName: "A", HelpText: "Use It!"})
+		} else {
+			title += "\nUnable to load this art!"
+		}
+
+		result, err := gaba.ConfirmationMessage(title, footer, gaba.MessageOptions{
+			ImagePath: previewPath,
+		})
+		if err != nil || result.IsNone() || previewPath == "" {
+			if err != nil || last {
+				return "", index
+			}
+
+			index++
+			continue
+		}
+
+		artPath := utils.ActiveMediaLayout().ArtPath(platform, game.Filename)
+		if err := saveArtPreview(previewPath, artPath); err != nil {
+			logger.Error("Unable to save art", "game", game.DisplayName, "error", err)
+			showTimedMessage("Unable to save art!")
+			continue
+		}
+		delete(previews, candidate.URL+candidate.SourcePath)
+
+		return artPath, index
+	}
+}
+
+func artSearchText(current string) (string, bool) {
+	text, err := gaba.Keyboard(current)
+	if err != nil || text.IsNone() || text.Unwrap() == "" {
+		return "", false
+	}
+
+	return text.Unwrap(), true
+}
+
+func saveArtPreview(previewPath, artPath string) error {
+	if err := os.MkdirAll(filepath.Dir(artPath), 0755); err != nil {
+		return err
+	}
+
+	return utils.MoveFile(previewPath, artPath)
+}
@@ -1,9 +1,13 @@
 package ui
 
 import (
+	"context"
 	"fmt"
+	"maps"
 	"mortar/models"
 	"mortar/utils"
+	"os"
+	"slices"
 	"time"
 
 	"github.com/UncleJunVIP/gabagool/pkg/gabagool"
@@ -33,52 +37,185 @@ func (a DownloadArtScreen) Name() sum.Int[models.ScreenName] {
 }
 
 func (a DownloadArtScreen) Draw() (value interface{}, exitCode int, e error) {
-	var artPaths []string
+	return nil, downloadArt(a.Platform, a.Games, a.DownloadType), nil
+}
 
-	gabagool.ProcessMessage("Downloading art...",
-		gabagool.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
-			for _, game := range a.Games {
-				artPath := utils.FindArt(a.Platform, game, a.DownloadType)
+// downloadArt fetches art for freshly downloaded games and lets the user review it. It returns 404 when nothing was
+// found for a batch.
+func downloadArt(platform models.Platform, games shared.Items, downloadType sum.Int[shared.ArtDownloadType]) int {
+	saved, missing, cancelled := fetchArt(platform, games, downloadType)
+	if cancelled {
+		return 0
+	}
 
-				if artPath != "" {
-					artPaths = append(artPaths, artPath)
-				}
+	if len(saved) == 0 {
+		if len(games) == 1 {
+			searchAgain, err := gabagool.ConfirmationMessage("No art found!\nSearch with different text?", []gabagool.FooterHelpItem{
+				{ButtonName: "B", HelpText: "Skip"},
+				{ButtonName: "A", HelpText: "Search"},
+			}, gabagool.MessageOptions{})
+			if err == nil && searchAgain.IsSome() {
+				pickArt(platform, games[0], downloadType)
 			}
-			return nil, nil
-		})
 
-	if len(artPaths) == 0 {
+			return 0
+		}
+
 		gabagool.ProcessMessage("No art found!",
 			gabagool.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
 				time.Sleep(time.Millisecond * 1500)
 				return nil, nil
 			})
 
-		return nil, 404, nil
-	} else if len(a.Games) > 1 {
-		gabagool.ProcessMessage(fmt.Sprintf("Art found for %d/%d games!", len(artPaths), len(a.Games)),
-			gabagool.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
-				time.Sleep(time.Millisecond * 1500)
-				return nil, nil
+		return 404
+	} else if len(games) > 1 {
+		showArtSummary(len(saved), len(games), missing)
+	}
+
+	reviewArt(platform, downloadType, saved)
+
+	time.Sleep(time.Millisecond * 100)
+
+	return 0
+}
+
+type artDownloadBatch struct {
+	headers   map[string]string
+	downloads []gabagool.Download
+}
+
+// fetchArt resolves, downloads and processes art for the games. It returns the saved art and the names of the
+// games nothing was found for, or reports that the lookup was cancelled before anything was downloaded.
+func fetchArt(platform models.Platform, games shared.Items, downloadType sum.Int[shared.ArtDownloadType]) (saved []utils.ResolvedArt, missing []string, cancelled bool) {
+	logger := gabagool.GetLoggerInstance()
+
+	var resolved []utils.ResolvedArt
+
+	cancelled = processWithProgress("Finding art...", len(games), func(ctx context.Context, tick func()) {
+		resolved, _ = utils.ResolveArtBatch(ctx, platform, games, downloadType, tick)
+	})
+
+	if cancelled {
+		logger.Info("Art lookup cancelled", "platform", platform.Name)
+		return nil, nil, true
+	}
+
+	// Downloads are batched by the headers their provider asked for, so only RomM covers get the host credentials.
+	var batches []artDownloadBatch
+	var fetched []utils.ResolvedArt
+
+	for _, art := range resolved {
+		if art.Err != nil {
+			logger.Debug("Unable to find art", "game", art.Game.DisplayName, "error", art.Err)
+			missing = append(missing, art.Game.DisplayName)
+			continue
+		}
+
+		if art.SourcePath != "" {
+			if _, err := utils.FetchArt(platform, art); err != nil {
+				logger.Error("Unable to copy local art", "game", art.Game.DisplayName, "error", err)
+				missing = append(missing, art.Game.DisplayName)
+				continue
+			}
+			saved = append(saved, art)
+			continue
+		}
+
+		download := gabagool.Download{
+			URL:         art.URL,
+			Location:    art.Location,
+			DisplayName: art.Game.DisplayName,
+		}
+
+		idx := slices.IndexFunc(batches, func(batch artDownloadBatch) bool {
+			return maps.Equal(batch.headers, art.Headers)
+		})
+		if idx == -1 {
+			batches = append(batches, artDownloadBatch{headers: art.Headers})
+			idx = len(batches) - 1
+		}
+		batches[idx].downloads = append(batches[idx].downloads, download)
+
+		fetched = append(fetched, art)
+	}
+
+	if len(fetched) > 0 {
+		if err := os.MkdirAll(utils.ArtDirectory(platform), 0755); err != nil {
+			logger.Error("Unable to create art directory", "error", err)
+		}
+
+		var completedDownloads []gabagool.Download
+
+		for _, batch := range batches {
+			headers := batch.headers
+			if headers == nil {
+				headers = map[string]string{}
+			}
+
+			res, err := gabagool.DownloadManager(batch.downloads, headers, true)
+			if err != nil {
+				logger.Error("Error downloading art", "error", err)
+			} else {
+				completedDownloads = append(completedDownloads, res.CompletedDownloads...)
+			}
+		}
+
+		for _, art := range fetched {
+			completed := slices.ContainsFunc(completedDownloads, func(d gabagool.Download) bool {
+				return d.Location == art.Location
 			})
+
+			if !completed {
+				common.DeleteFile(art.Location)
+				missing = append(missing, art.Game.DisplayName)
+				continue
+			}
+
+			if err := utils.ProcessArt(platform, art.Game, art.Location); err != nil {
+				logger.Error("Unable to process art", "game", art.Game.DisplayName, "error", err)
+				missing = append(missing, art.Game.DisplayName)
+				continue
+			}
+
+			saved = append(saved, art)
+		}
+	}
+
+	return saved, missing, false
+}
+
+func showArtSummary(found, total int, missing []string) {
+	gabagool.ProcessMessage(fmt.Sprintf("Art found for %d/%d games!", found, total),
+		gabagool.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+			time.Sleep(time.Millisecond * 1500)
+			return nil, nil
+		})
+
+	if len(missing) > 0 {
+		_, _ = gabagool.ConfirmationMessage(summarizeNames("No art for:", missing, 5),
+			[]gabagool.FooterHelpItem{
+				{ButtonName: "A", HelpText: "Continue"},
+			},
+			gabagool.MessageOptions{})
 	}
+}
 
-	for _, artPath := range artPaths {
+// reviewArt shows each piece of art. Turning one down opens the picker with the other candidates for that game.
+func reviewArt(platform models.Platform, downloadType sum.Int[shared.ArtDownloadType], saved []utils.ResolvedArt) {
+	for _, art := range saved {
 		result, err := gabagool.ConfirmationMessage("Found This Art!",
 			[]gabagool.FooterHelpItem{
-				{ButtonName: "B", HelpText: "I'll Find My Own"},
+				{ButtonName: "B", HelpText: "More Options"},
 				{ButtonName: "A", HelpText: "Use It!"},
 			},
 			gabagool.MessageOptions{
-				ImagePath: artPath,
+				ImagePath: art.Location,
 			})
 
 		if err != nil || result.IsNone() {
-			common.DeleteFile(artPath)
+			if pickArt(platform, art.Game, downloadType) == "" {
+				common.DeleteFile(art.Location)
+			}
 		}
 	}
-
-	time.Sleep(time.Millisecond * 100)
-
-	return nil, 0, nil
 }
@@ -1,12 +1,12 @@
 package ui
 
 import (
-	"encoding/base64"
 	"mortar/clients"
 	"mortar/models"
 	"mortar/state"
 	"mortar/utils"
 	"net/url"
+	"os"
 	"path/filepath"
 	"slices"
 	"strconv"
@@ -41,17 +41,18 @@ func (d DownloadScreen) Name() sum.Int[models.ScreenName] {
 func (d DownloadScreen) Draw() (value interface{}, exitCode int, e error) {
 	logger := gaba.GetLoggerInstance()
 
-	downloads := BuildDownload(d.Platform, d.SelectedGames)
+	if !requireOnline(d.Platform) {
+		return nil, 1, nil
+	}
 
-	headers := make(map[string]string)
+	toDownload := append(shared.Items{}, d.SelectedGames...)
+	if d.Platform.IsArcade {
+		toDownload = append(toDownload, arcadeDependencies(d.Platform, d.Games, d.SelectedGames)...)
+	}
 
-	if d.Platform.Host.HostType == shared.HostTypes.ROMM {
-		auth := d.Platform.Host.Username + ":" + d.Platform.Host.Password
-		authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
-		headers["Authorization"] = authHeader
+	downloads := BuildDownload(d.Platform, toDownload)
 
-		logger.Debug("RomM Auth Header", "header", authHeader)
-	}
+	headers := buildDownloadHeaders(d.Platform.Host)
 
 	slices.SortFunc(downloads, func(a, b gaba.Download) int {
 		return strings.Compare(strings.ToLower(a.DisplayName), strings.ToLower(b.DisplayName))
@@ -81,7 +82,8 @@ func (d DownloadScreen) Draw() (value interface{}, exitCode int, e error) {
 
 	var downloadedGames []shared.Item
 
-	for _, g := range d.Games {
+	// BIOS and device sets pulled in for arcade games are left out so they aren't post-processed or given art.
+	for _, g := range d.SelectedGames {
 		if slices.ContainsFunc(res.CompletedDownloads, func(d gaba.Download) bool {
 			return d.DisplayName == g.DisplayName
 		}) {
@@ -98,7 +100,7 @@ func BuildDownload(platform models.Platform, games shared.Items) []gaba.Download
 
 		var downloadLocation string
 		if utils.IsDev() {
-			romDirectory := strings.ReplaceAll(platform.LocalDirectory, common.RomDirectory, utils.GetRomDirectory())
+			romDirectory := strings.ReplaceAll(platform.LocalDirectory, utils.ActiveMediaLayout().RomDirectory, utils.GetRomDirectory())
 			downloadLocation = filepath.Join(romDirectory, g.Filename)
 		} else {
 			downloadLocation = filepath.Join(platform.LocalDirectory, g.Filename)
@@ -128,3 +130,54 @@ func BuildDownload(platform models.Platform, games shared.Items) []gaba.Download
 
 	return downloads
 }
+
+// arcadeDependencies finds the BIOS, device and parent sets the selected games need on the host that aren't on the
+// device yet.
+func arcadeDependencies(platform models.Platform, games shared.Items, selected shared.Items) shared.Items {
+	romset := loadArcadeRomset(platform)
+	if romset == nil || romset.Catalog == nil {
+		return nil
+	}
+	catalog := romset.Catalog
+
+	localDirectory := utils.ResolveLocalDirectory(platform)
+
+	var dependencies shared.Items
+	for _, game := range selected {
+		for _, filename := range catalog.Dependencies(game.Filename) {
+			if slices.ContainsFunc(selected, func(g shared.Item) bool { return strings.EqualFold(g.Filename, filename) }) ||
+				slices.ContainsFunc(dependencies, func(g shared.Item) bool { return strings.EqualFold(g.Filename, filename) }) {
+				continue
+			}
+
+			if _, err := os.Stat(filepath.Join(localDirectory, filename)); err == nil {
+				continue
+			}
+
+			idx := slices.IndexFunc(games, func(g shared.Item) bool { return strings.EqualFold(g.Filename, filename) })
+			if idx == -1 {
+				gaba.GetLoggerInstance().Debug("Required arcade set not found on host", "game", game.Filename, "dependency", filename)
+				continue
+			}
+
+			dependency := games[idx]
+			if dependency.DisplayName == "" {
+				dependency.DisplayName = catalog.Title(filename)
+			}
+			dependencies = append(dependencies, dependency)
+		}
+	}
+
+	return dependencies
+}
+
+func buildDownloadHeaders(host models.Host) map[string]string {
+	headers := make(map[string]string)
+
+	if host.HostType == shared.HostTypes.ROMM {
+		client := clients.NewRomMClient(host.RootURI, host.Port, host.Username, host.Password)
+		return client.BuildDownloadHeaders()
+	}
+
+	return headers
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"mortar/models"
+	"mortar/state"
+	"mortar/utils"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+)
+
+// FilterPresetsScreen switches a platform's filter presets on and off. The choice is saved on the platform.
+type FilterPresetsScreen struct {
+	Platform     models.Platform
+	SearchFilter string
+}
+
+func InitFilterPresetsScreen(platform models.Platform, searchFilter string) FilterPresetsScreen {
+	return FilterPresetsScreen{
+		Platform:     platform,
+		SearchFilter: searchFilter,
+	}
+}
+
+func (f FilterPresetsScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.FilterPresets
+}
+
+func (f FilterPresetsScreen) Draw() (value interface{}, exitCode int, e error) {
+	var items []gaba.ItemWithOptions
+	for _, preset := range utils.PlatformFilterPresets(f.Platform) {
+		selected := 1
+		if preset.Active {
+			selected = 0
+		}
+
+		items = append(items, gaba.ItemWithOptions{
+			Item: gaba.MenuItem{
+				Text:     preset.Name,
+				Metadata: preset,
+			},
+			Options: []gaba.Option{
+				{DisplayName: "On", Value: true},
+				{DisplayName: "Off", Value: false},
+			},
+			SelectedOption: selected,
+		})
+	}
+
+	result, err := gaba.OptionsList(
+		fmt.Sprintf("%s Filter Presets", f.Platform.Name),
+		items,
+		[]gaba.FooterHelpItem{
+			{ButtonName: "B", HelpText: "Cancel"},
+			{ButtonName: "←→", HelpText: "Toggle"},
+			{ButtonName: "Start", HelpText: "Save"},
+		},
+	)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if result.IsNone() {
+		return nil, 2, nil
+	}
+
+	// Only presets switched away from their config default are kept, so changing the default still takes effect.
+	toggles := make(map[string]bool)
+	for _, item := range result.Unwrap().Items {
+		preset := item.Item.Metadata.(utils.FilterPreset)
+		if active := item.SelectedOption == 0; active != preset.Enabled {
+			toggles[preset.Name] = active
+		}
+	}
+
+	platform, err := utils.SavePlatformSettings(state.GetAppState().Config, f.Platform, func(p *models.Platform) {
+		p.FilterPresets = toggles
+	})
+	if err != nil {
+		gaba.GetLoggerInstance().Error("Unable to save filter presets", "platform", f.Platform.Name, "error", err)
+	}
+
+	return platform, 0, nil
+}
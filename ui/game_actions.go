@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"mortar/models"
+	"mortar/utils"
+	"time"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"qlova.tech/sum"
+)
+
+// GameActionsScreen is the menu behind X in the games list.
+type GameActionsScreen struct {
+	Platform     models.Platform
+	SearchFilter string
+	Focus        GameListFocus
+}
+
+func InitGameActionsScreen(platform models.Platform, searchFilter string, focus GameListFocus) GameActionsScreen {
+	return GameActionsScreen{
+		Platform:     platform,
+		SearchFilter: searchFilter,
+		Focus:        focus,
+	}
+}
+
+func (a GameActionsScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.GameActions
+}
+
+func (a GameActionsScreen) Draw() (value interface{}, exitCode int, e error) {
+	type gameAction struct {
+		name   string
+		action sum.Int[models.GameAction]
+	}
+
+	actions := []gameAction{
+		{"Search", models.GameActions.Search},
+	}
+
+	if len(a.Focus.Games) > 0 {
+		actions = append(actions, gameAction{wishlistActionText(a.Platform, a.Focus.Games), models.GameActions.ToggleWishlist})
+	}
+
+	if len(a.Focus.Letters) > 1 {
+		actions = append(actions, gameAction{"Jump to Letter", models.GameActions.JumpToLetter})
+	}
+
+	actions = append(actions, gameAction{"Sort: " + sortLabel(a.Platform.Sort), models.GameActions.Sort})
+
+	if a.SearchFilter != "" {
+		actions = append(actions, gameAction{"Save Search", models.GameActions.SaveSearch})
+	}
+
+	if len(a.Platform.SavedQueries) > 0 {
+		actions = append(actions, gameAction{"Smart Filters", models.GameActions.SmartFilters})
+	}
+
+	if len(utils.PlatformFilterPresets(a.Platform)) > 0 {
+		actions = append(actions, gameAction{"Filter Presets", models.GameActions.FilterPresets})
+	}
+
+	actions = append(actions, gameAction{"Refresh List", models.GameActions.Refresh})
+
+	var menuItems []gaba.MenuItem
+	for _, action := range actions {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     action.name,
+			Selected: false,
+			Focused:  false,
+			Metadata: action.action,
+		})
+	}
+
+	title := a.Platform.Name
+	if fetchedAt, ok := utils.CatalogFetchedAt(a.Platform); ok {
+		title += " | Cached " + utils.FormatCacheAge(time.Since(fetchedAt))
+	}
+
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if selection.IsNone() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	return selection.Unwrap().SelectedItem.Metadata.(sum.Int[models.GameAction]), 0, nil
+}
+
+// wishlistActionText matches what ToggleWishlist will do: add when any of the games isn't starred yet.
+func wishlistActionText(platform models.Platform, games shared.Items) string {
+	for _, game := range games {
+		if !utils.IsWishlisted(platform, game) {
+			if len(games) == 1 {
+				return "Add to Wishlist"
+			}
+			return fmt.Sprintf("Add %d to Wishlist", len(games))
+		}
+	}
+
+	if len(games) == 1 {
+		return "Remove from Wishlist"
+	}
+	return fmt.Sprintf("Remove %d from Wishlist", len(games))
+}
@@ -1,13 +1,10 @@
 package ui
 
 import (
-	"encoding/json"
 	"fmt"
 	"mortar/models"
 	"mortar/state"
 	"mortar/utils"
-	"os"
-	"path"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -18,36 +15,67 @@ import (
 	"qlova.tech/sum"
 )
 
+var warnedRomsets = make(map[string]bool)
+
+// GameListFocus is what the games list was showing when its actions were opened: the letter index and the games that
+// were selected or highlighted.
+type GameListFocus struct {
+	Letters []utils.LetterEntry
+	Games   shared.Items
+}
+
 type GameList struct {
 	Platform     models.Platform
 	Games        shared.Items
 	SearchFilter string
+	Offline      bool
 }
 
 func InitGamesList(platform models.Platform, games shared.Items, searchFilter string) GameList {
-	var g shared.Items
-
 	if len(games) > 0 {
-		g = games
-	} else {
-		process, err := gaba.ProcessMessage(fmt.Sprintf("Loading %s...", platform.Name), gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
-			var err error
-			g, err = loadGamesList(platform)
-			return g, err
-		})
-		if err != nil {
-			return GameList{}
-		}
+		return newGamesList(platform, games, searchFilter)
+	}
+
+	return fetchGamesList(platform, searchFilter, false)
+}
+
+// RefreshGamesList checks the host for a newer list even when the cached one hasn't expired.
+func RefreshGamesList(platform models.Platform, searchFilter string) GameList {
+	if !utils.IsHostReachable(platform.Host) {
+		_ = utils.ProbeHost(platform.Host)
+	}
+
+	return fetchGamesList(platform, searchFilter, true)
+}
 
-		g = process.Result.(shared.Items)
+func fetchGamesList(platform models.Platform, searchFilter string, refresh bool) GameList {
+	message := fmt.Sprintf("Loading %s...", platform.Name)
+	if refresh {
+		message = fmt.Sprintf("Refreshing %s...", platform.Name)
 	}
 
-	state.SetCurrentFullGamesList(g)
+	process, err := gaba.ProcessMessage(message, gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+		return loadGamesList(platform, refresh)
+	})
+	if err != nil {
+		return GameList{}
+	}
+
+	return newGamesList(platform, process.Result.(shared.Items), searchFilter)
+}
+
+func newGamesList(platform models.Platform, games shared.Items, searchFilter string) GameList {
+	state.SetCurrentFullGamesList(games)
+
+	if len(games) > 0 {
+		recordGameCount(platform, len(games))
+	}
 
 	return GameList{
 		Platform:     platform,
-		Games:        g,
+		Games:        games,
 		SearchFilter: searchFilter,
+		Offline:      !utils.IsHostReachable(platform.Host),
 	}
 }
 
@@ -61,8 +89,21 @@ func (gl GameList) Draw() (game interface{}, exitCode int, e error) {
 
 	itemList := gl.Games
 
+	var romset *utils.ArcadeRomset
+	var catalog *utils.ArcadeCatalog
+	if gl.Platform.IsArcade {
+		romset = loadArcadeRomset(gl.Platform)
+		if romset != nil {
+			catalog = romset.Catalog
+		}
+	}
+
 	for idx, _ := range itemList {
-		if gl.Platform.IsArcade {
+		if romset != nil {
+			itemList[idx].DisplayName = romset.Title(itemList[idx].Filename)
+		}
+
+		if gl.Platform.IsArcade && itemList[idx].DisplayName == "" {
 			itemList[idx].DisplayName = utils.ArcadeMapping[itemList[idx].Filename]
 		}
 
@@ -71,23 +112,40 @@ func (gl GameList) Draw() (game interface{}, exitCode int, e error) {
 		}
 	}
 
-	if !gl.Platform.IsArcade && len(host.Filters.InclusiveFilters) > 0 || len(host.Filters.ExclusiveFilters) > 0 {
-		filters := host.Filters
+	itemList = applyPlatformFilters(gl.Platform, itemList)
 
-		if gl.Platform.SkipExclusiveFilters {
-			filters.ExclusiveFilters = []string{}
+	if catalog != nil {
+		var visible shared.Items
+		for _, item := range itemList {
+			if !catalog.IsHidden(item.Filename, gl.Platform.ArcadeFilters) {
+				visible = append(visible, item)
+			}
 		}
+		itemList = visible
+	}
 
-		if gl.Platform.SkipInclusiveFilters {
-			filters.InclusiveFilters = []string{}
-		}
+	itemList = slices.Clone(itemList)
+	utils.SortGames(itemList, gl.Platform.Sort, utils.CatalogMetadata(gl.Platform))
 
-		itemList = filterList(gl.Games, filters)
+	if !gl.Platform.Sort.IsDefault() {
+		title += " | " + sortLabel(gl.Platform.Sort)
 	}
 
 	if gl.SearchFilter != "" {
 		title = "[Search: \"" + gl.SearchFilter + "\"]"
-		itemList = filterList(itemList, models.Filters{InclusiveFilters: []string{gl.SearchFilter}})
+
+		results, err := searchGames(gl.Platform, itemList, gl.SearchFilter)
+		if err != nil {
+			showInvalidSearch(err)
+			return nil, 5, nil
+		}
+		itemList = results
+	}
+
+	if gl.Offline {
+		title += " [Offline]"
+	} else if fetchedAt, ok := utils.CatalogFetchedAt(gl.Platform); ok && time.Since(fetchedAt) >= time.Minute {
+		title += " | Cached " + utils.FormatCacheAge(time.Since(fetchedAt))
 	}
 
 	if len(itemList) == 0 {
@@ -100,6 +158,15 @@ func (gl GameList) Draw() (game interface{}, exitCode int, e error) {
 					return nil, nil
 				},
 			)
+		} else if gl.Offline {
+			gaba.ProcessMessage(
+				fmt.Sprintf("%s is unreachable and\nhas no cached list for %s", host.DisplayName, gl.Platform.Name),
+				gaba.ProcessMessageOptions{ShowThemeBackground: true},
+				func() (interface{}, error) {
+					time.Sleep(time.Second * 2)
+					return nil, nil
+				},
+			)
 		} else {
 			gaba.ProcessMessage(
 				fmt.Sprintf("No games found for %s", gl.Platform.Name),
@@ -113,22 +180,38 @@ func (gl GameList) Draw() (game interface{}, exitCode int, e error) {
 		return nil, 404, nil
 	}
 
+	foreignSets := 0
+
 	var itemEntries []gaba.MenuItem
 	for _, game := range itemList {
+		text := game.DisplayName
+		if romset != nil && romset.Chosen && !romset.Contains(game.Filename) {
+			text = "[!] " + text
+			foreignSets++
+		}
+
+		if utils.IsWishlisted(gl.Platform, game) {
+			text = "[*] " + text
+		}
+
 		itemEntries = append(itemEntries, gaba.MenuItem{
-			Text:     game.DisplayName,
+			Text:     text,
 			Selected: false,
 			Focused:  false,
 			Metadata: game,
 		})
 	}
 
+	if romset != nil {
+		warnAboutForeignSets(gl.Platform, romset, foreignSets)
+	}
+
 	options := gaba.DefaultListOptions(title, itemEntries)
 	options.EnableAction = true
 	options.EnableMultiSelect = true
 	options.FooterHelpItems = []gaba.FooterHelpItem{
 		{ButtonName: "B", HelpText: "Back"},
-		{ButtonName: "X", HelpText: "Search"},
+		{ButtonName: "X", HelpText: "Actions"},
 		{ButtonName: "Select", HelpText: "Multi"},
 		{ButtonName: "A", HelpText: "Select"},
 	}
@@ -151,101 +234,148 @@ func (gl GameList) Draw() (game interface{}, exitCode int, e error) {
 
 		return selections, 0, nil
 	} else if selection.IsSome() && selection.Unwrap().ActionTriggered {
-		return nil, 4, nil
+		focus := GameListFocus{Letters: gl.letterIndex(itemList)}
+
+		for _, item := range selection.Unwrap().SelectedItems {
+			focus.Games = append(focus.Games, item.Metadata.(shared.Item))
+		}
+
+		if len(focus.Games) == 0 && selection.Unwrap().SelectedItem != nil {
+			focus.Games = shared.Items{selection.Unwrap().SelectedItem.Metadata.(shared.Item)}
+		}
+
+		if selection.Unwrap().SelectedIndex != -1 {
+			state.SetLastSelectedPosition(selection.Unwrap().SelectedIndex, selection.Unwrap().VisiblePosition)
+		}
+
+		return focus, 4, nil
 	}
 
 	return nil, 2, err
 }
 
-func loadGamesList(platform models.Platform) (games shared.Items, e error) {
+// letterIndex is where each letter starts in the list as shown, nil when it isn't ordered by name.
+func (gl GameList) letterIndex(itemList shared.Items) []utils.LetterEntry {
+	if gl.Platform.Sort.By != "" && gl.Platform.Sort.By != "name" {
+		return nil
+	}
+
+	if query, err := utils.ParseQuery(gl.SearchFilter); err != nil || query.IsRanked() {
+		return nil
+	}
+
+	var hostIndex map[string]int
+	if gl.Platform.Sort.IsDefault() && gl.SearchFilter == "" {
+		hostIndex = utils.CatalogCharIndex(gl.Platform)
+	}
+
+	return utils.LetterIndex(itemList, hostIndex)
+}
+
+// loadGamesList serves a platform's list from the catalog cache while it is within the TTL and asks the host
+// whether it changed once it isn't. A stale list is used when the host can't be reached or the fetch fails.
+func loadGamesList(platform models.Platform, refresh bool) (games shared.Items, e error) {
 	logger := gaba.GetLoggerInstance()
 
-	cacheResults := checkCache(platform)
-	if cacheResults != nil {
-		return cacheResults, nil
+	defer lockCatalog(platform)()
+
+	cached := utils.LoadCatalogCache(platform)
+
+	if cached != nil && !utils.IsHostReachable(platform.Host) {
+		logger.Debug("Host is offline, using cached list", "platform", platform.Name)
+		return cached.Items, nil
 	}
 
-	items, err := FetchListStateless(platform)
-	if err != nil {
-		logger.Error("Error downloading Item List", "error", err)
+	if cached != nil && !refresh && !cached.IsExpired() {
+		return cached.Items, nil
 	}
 
-	if len(items) == 0 {
+	if !utils.IsHostReachable(platform.Host) {
+		logger.Debug("Host is offline and the platform has no cached list", "platform", platform.Name)
 		return nil, nil
 	}
 
-	slices.SortFunc(items, func(a, b shared.Item) int {
-		return strings.Compare(strings.ToLower(a.Filename), strings.ToLower(b.Filename))
-	})
+	validators := revalidateList(platform, cached)
 
-	cache(platform, items)
-	return items, nil
+	if cached != nil && validators.NotModified {
+		logger.Debug("Cached list is still current", "platform", platform.Name)
 
-}
+		cached.FetchedAt = time.Now()
+		if err := utils.SaveCatalogCache(platform, cached); err != nil {
+			logger.Debug("Unable to update catalog cache", "error", err)
+		}
 
-func checkCache(platform models.Platform) shared.Items {
-	logger := gaba.GetLoggerInstance()
+		return cached.Items, nil
+	}
 
-	cwd, err := os.Getwd()
+	list, err := FetchListStateless(platform)
 	if err != nil {
-		logger.Debug("Unable to get current working directory for loading cached Megathread", "error", err)
-		return nil
+		logger.Error("Error downloading Item List", "error", err)
 	}
 
-	if platform.Host.HostType == shared.HostTypes.MEGATHREAD {
-		cachePath := filepath.Join(cwd, ".cache", utils.CachedMegaThreadJsonFilename(platform.Host.DisplayName, platform.Name))
-		if _, err := os.Stat(cachePath); os.IsNotExist(err) {
-			return nil
-		}
+	items := list.Items
 
-		data, err := os.ReadFile(cachePath)
-		if err != nil {
-			logger.Debug("Unable to read cached Megathread JSON file", "error", err)
-			return nil
+	if len(items) == 0 {
+		if cached != nil {
+			logger.Debug("Unable to refresh list, using stale cache", "platform", platform.Name)
+			return cached.Items, nil
 		}
+		return nil, nil
+	}
 
-		var items shared.Items
-		err = json.Unmarshal(data, &items)
-		if err != nil {
-			logger.Debug("Unable to unmarshal cached Megathread JSON data", "error", err)
-			return nil
-		}
+	slices.SortFunc(items, func(a, b shared.Item) int {
+		return strings.Compare(strings.ToLower(a.Filename), strings.ToLower(b.Filename))
+	})
 
-		return items
+	list.FetchedAt = time.Now()
+	list.ETag = validators.ETag
+	list.LastModified = validators.LastModified
+
+	err = utils.SaveCatalogCache(platform, &list)
+	if err != nil {
+		logger.Debug("Unable to cache list", "platform", platform.Name, "error", err)
 	}
 
-	return nil
+	return items, nil
 }
 
-func cache(platform models.Platform, gamesList shared.Items) {
-	if platform.Host.HostType == shared.HostTypes.MEGATHREAD {
-		logger := gaba.GetLoggerInstance()
+// loadArcadeRomset returns the romset the platform's core expects, or nil when it can't be read.
+func loadArcadeRomset(platform models.Platform) *utils.ArcadeRomset {
+	load := func() (interface{}, error) {
+		return utils.LoadArcadeRomset(platform)
+	}
 
-		jsonData, err := json.Marshal(gamesList)
-		if err != nil {
-			logger.Debug("Unable to get marshal JSON for Megathread", "error", err)
-			return
-		}
+	var result interface{}
+	var err error
 
-		cwd, err := os.Getwd()
-		if err != nil {
-			logger.Debug("Unable to get current working directory for caching Megathread", "error", err)
-			return
-		}
+	if utils.IsArcadeRomsetLoaded(platform) {
+		result, err = load()
+	} else {
+		var process gaba.ProcessReturn
+		process, err = gaba.ProcessMessage(fmt.Sprintf("Loading %s romset...", platform.Name), gaba.ProcessMessageOptions{ShowThemeBackground: true}, load)
+		result = process.Result
+	}
 
-		dir := filepath.Join(cwd, ".cache")
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			logger.Debug("Unable to make cache directory", "error", err)
-			return
-		}
+	if err != nil {
+		gaba.GetLoggerInstance().Error("Unable to load arcade romset", "romset", utils.ArcadeRomsetFile(platform), "error", err)
+		return nil
+	}
 
-		filePath := path.Join(cwd, ".cache", utils.CachedMegaThreadJsonFilename(platform.Host.DisplayName, platform.Name))
-		err = os.WriteFile(filePath, jsonData, 0644)
-		if err != nil {
-			logger.Debug("Unable to write JSON to file for Megathread", "error", err)
-			return
-		}
+	return result.(*utils.ArcadeRomset)
+}
 
-		logger.Debug("Cached Megathread Platform", "platform_name", platform.Name)
+// warnAboutForeignSets tells the user once per session when the host has sets that aren't part of the platform's
+// romset, as those most likely won't run on the core.
+func warnAboutForeignSets(platform models.Platform, romset *utils.ArcadeRomset, foreign int) {
+	key := platformKey(platform)
+	if foreign == 0 || warnedRomsets[key] {
+		return
 	}
+	warnedRomsets[key] = true
+
+	_, _ = gaba.ConfirmationMessage(fmt.Sprintf("%d sets on this host are not in the\n%s romset.\nThey are marked with [!]", foreign, romset.Name),
+		[]gaba.FooterHelpItem{
+			{ButtonName: "A", HelpText: "Continue"},
+		},
+		gaba.MessageOptions{})
 }
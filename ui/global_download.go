@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"mortar/models"
+	"mortar/state"
+	"mortar/utils"
+	"slices"
+	"strings"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"qlova.tech/sum"
+)
+
+// GlobalDownloadScreen downloads games picked from the global search or the wishlist, each into its own platform's
+// folder. It returns the games that were downloaded.
+type GlobalDownloadScreen struct {
+	Selection models.SearchSelection
+}
+
+func InitGlobalDownloadScreen(selection models.SearchSelection) GlobalDownloadScreen {
+	return GlobalDownloadScreen{
+		Selection: selection,
+	}
+}
+
+func (g GlobalDownloadScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.GlobalDownload
+}
+
+type platformDownloads struct {
+	Platform  models.Platform
+	Games     shared.Items
+	Downloads []gaba.Download
+}
+
+func (g GlobalDownloadScreen) Draw() (value interface{}, exitCode int, e error) {
+	logger := gaba.GetLoggerInstance()
+	config := state.GetAppState().Config
+
+	groups := groupSearchResults(g.Selection.Selected)
+
+	// Hosts need their own headers, so there is one download run per host.
+	var hosts []string
+	byHost := make(map[string][]*platformDownloads)
+
+	for _, group := range groups {
+		if !requireOnline(group.Platform) {
+			continue
+		}
+
+		toDownload := append(shared.Items{}, group.Games...)
+		if group.Platform.IsArcade {
+			if cached := utils.LoadCatalogCache(group.Platform); cached != nil {
+				toDownload = append(toDownload, arcadeDependencies(group.Platform, cached.Items, group.Games)...)
+			}
+		}
+
+		group.Downloads = BuildDownload(group.Platform, toDownload)
+
+		host := group.Platform.Host.DisplayName
+		if _, ok := byHost[host]; !ok {
+			hosts = append(hosts, host)
+		}
+		byHost[host] = append(byHost[host], group)
+	}
+
+	if len(hosts) == 0 {
+		return nil, 1, nil
+	}
+
+	var completed []gaba.Download
+
+	for _, host := range hosts {
+		var downloads []gaba.Download
+		for _, group := range byHost[host] {
+			downloads = append(downloads, group.Downloads...)
+		}
+
+		slices.SortFunc(downloads, func(a, b gaba.Download) int {
+			return strings.Compare(strings.ToLower(a.DisplayName), strings.ToLower(b.DisplayName))
+		})
+
+		logger.Debug("Starting ROM download", "host", host, "downloads", downloads)
+
+		res, err := gaba.DownloadManager(downloads, buildDownloadHeaders(byHost[host][0].Platform.Host), config.DownloadArt)
+		if err != nil {
+			logger.Error("Error downloading", "host", host, "error", err)
+			continue
+		}
+
+		for _, d := range res.FailedDownloads {
+			common.DeleteFile(d.Location)
+		}
+
+		completed = append(completed, res.CompletedDownloads...)
+	}
+
+	if len(completed) == 0 {
+		return nil, 1, nil
+	}
+
+	var downloaded models.SearchResults
+
+	for _, host := range hosts {
+		for _, group := range byHost[host] {
+			// Only the games that were picked are post-processed, not the arcade sets that came along with them.
+			var launchableGames shared.Items
+			for idx, game := range group.Games {
+				if !slices.ContainsFunc(completed, func(d gaba.Download) bool {
+					return d.Location == group.Downloads[idx].Location
+				}) {
+					continue
+				}
+
+				downloaded = append(downloaded, models.SearchResult{Platform: group.Platform, Item: game})
+				launchableGames = append(launchableGames, utils.PostProcessDownload(group.Platform, game, config)...)
+			}
+
+			if config.DownloadArt && len(launchableGames) > 0 {
+				downloadArt(group.Platform, utils.UniqueArtGames(launchableGames), config.ArtDownloadType)
+			}
+		}
+	}
+
+	return downloaded, 0, nil
+}
+
+func groupSearchResults(results models.SearchResults) []*platformDownloads {
+	var groups []*platformDownloads
+
+	for _, result := range results {
+		idx := slices.IndexFunc(groups, func(group *platformDownloads) bool {
+			return platformKey(group.Platform) == platformKey(result.Platform)
+		})
+
+		if idx == -1 {
+			groups = append(groups, &platformDownloads{Platform: result.Platform})
+			idx = len(groups) - 1
+		}
+
+		groups[idx].Games = append(groups[idx].Games, result.Item)
+	}
+
+	return groups
+}
@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"fmt"
+	"mortar/clients"
+	"mortar/models"
+	"mortar/state"
+	"mortar/utils"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"qlova.tech/sum"
+)
+
+// GlobalSearchScreen searches every platform on every host. Without results it asks for a query first.
+type GlobalSearchScreen struct {
+	Query   string
+	Results models.SearchResults
+}
+
+func InitGlobalSearchScreen(query string, results models.SearchResults) GlobalSearchScreen {
+	return GlobalSearchScreen{
+		Query:   query,
+		Results: results,
+	}
+}
+
+func (s GlobalSearchScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.GlobalSearch
+}
+
+func (s GlobalSearchScreen) Draw() (value interface{}, exitCode int, e error) {
+	logger := gaba.GetLoggerInstance()
+
+	query := s.Query
+	results := s.Results
+
+	if results == nil {
+		res, err := gaba.Keyboard(s.Query)
+		if err != nil {
+			logger.Error("Error with blocking keyboard", "error", err)
+			return nil, -1, err
+		}
+
+		if res.IsNone() || strings.TrimSpace(res.Unwrap()) == "" {
+			return nil, 2, nil
+		}
+
+		query = strings.TrimSpace(res.Unwrap())
+
+		parsed, err := utils.ParseQuery(query)
+		if err != nil {
+			showInvalidSearch(err)
+			return models.SearchSelection{Query: query}, 4, nil
+		}
+
+		process, err := gaba.ProcessMessage(fmt.Sprintf("Searching for \"%s\"...", query), gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+			return searchEverything(parsed), nil
+		})
+		if err != nil {
+			return nil, -1, err
+		}
+
+		results = process.Result.(models.SearchResults)
+	}
+
+	if len(results) == 0 {
+		gaba.ProcessMessage(
+			fmt.Sprintf("No results found for \"%s\"", query),
+			gaba.ProcessMessageOptions{ShowThemeBackground: true},
+			func() (interface{}, error) {
+				time.Sleep(time.Second * 2)
+				return nil, nil
+			},
+		)
+		return models.SearchSelection{Query: query}, 404, nil
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, result := range results {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("%s | %s", result.Platform.Name, result.Item.DisplayName),
+			Selected: false,
+			Focused:  false,
+			Metadata: result,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("[Search Everything: \"%s\"]", query), menuItems)
+	options.EnableAction = true
+	options.EnableMultiSelect = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "X", HelpText: "Search"},
+		{ButtonName: "Select", HelpText: "Multi"},
+		{ButtonName: "A", HelpText: "Download"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if selection.IsSome() && selection.Unwrap().ActionTriggered {
+		return models.SearchSelection{Query: query}, 4, nil
+	} else if selection.IsSome() && selection.Unwrap().SelectedIndex != -1 {
+		var selected models.SearchResults
+		for _, item := range selection.Unwrap().SelectedItems {
+			selected = append(selected, item.Metadata.(models.SearchResult))
+		}
+
+		return models.SearchSelection{Query: query, Results: results, Selected: selected}, 0, nil
+	}
+
+	return nil, 2, nil
+}
+
+// searchEverything matches the query against every platform's list. RomM hosts that can be reached use RomM's own
+// search for the free text, everything else goes through the catalog cache. Results are grouped by platform in config
+// order.
+func searchEverything(query utils.Query) models.SearchResults {
+	logger := gaba.GetLoggerInstance()
+
+	var results models.SearchResults
+
+	for _, host := range state.GetAppState().Config.Hosts {
+		if host.HostType == shared.HostTypes.ROMM && utils.IsHostReachable(host) && query.Text() != "" {
+			found, err := searchRomM(host, query)
+			if err == nil {
+				results = append(results, found...)
+				continue
+			}
+
+			logger.Error("Unable to search RomM, using cached lists", "host", host.DisplayName, "error", err)
+		}
+
+		for _, platform := range host.Platforms {
+			platform.Host = host
+
+			items, err := loadGamesList(platform, false)
+			if err != nil {
+				logger.Debug("Unable to load list for search", "platform", platform.Name, "error", err)
+				continue
+			}
+
+			items = slices.Clone(items)
+			setSearchDisplayNames(platform, items)
+
+			matches := queryGames(query, platform, applyPlatformFilters(platform, items), utils.CatalogMetadata(platform))
+			for _, item := range matches {
+				results = append(results, models.SearchResult{Platform: platform, Item: item})
+			}
+		}
+	}
+
+	return results
+}
+
+func searchRomM(host models.Host, query utils.Query) (models.SearchResults, error) {
+	client := clients.NewRomMClient(host.RootURI, host.Port, host.Username, host.Password)
+
+	roms, err := client.SearchRoms(query.Text())
+	if err != nil {
+		return nil, err
+	}
+
+	byPlatform := make(map[string]shared.Items)
+	metadata := make(models.GameMetadataIndex, len(roms))
+	for _, rom := range roms {
+		id := strconv.Itoa(rom.PlatformID)
+		byPlatform[id] = append(byPlatform[id], rom.Item())
+		metadata[strconv.Itoa(rom.ID)] = rom.Metadata()
+	}
+
+	var results models.SearchResults
+	for _, platform := range host.Platforms {
+		platform.Host = host
+
+		items := byPlatform[platform.RomMPlatformID]
+		if len(items) == 0 {
+			continue
+		}
+
+		setSearchDisplayNames(platform, items)
+		items = applyPlatformFilters(platform, items)
+
+		// RomM matches more loosely than the local search, anything it found that doesn't score is kept at the end
+		// as long as it passes the filters.
+		ranked := queryGames(query, platform, items, metadata)
+		for _, item := range queryGames(query.FiltersOnly(), platform, items, metadata) {
+			if !slices.ContainsFunc(ranked, func(r shared.Item) bool { return r.RomID == item.RomID }) {
+				ranked = append(ranked, item)
+			}
+		}
+
+		for _, item := range ranked {
+			results = append(results, models.SearchResult{Platform: platform, Item: item})
+		}
+	}
+
+	return results, nil
+}
+
+func setSearchDisplayNames(platform models.Platform, items shared.Items) {
+	for idx := range items {
+		if platform.IsArcade {
+			items[idx].DisplayName = utils.ArcadeMapping[items[idx].Filename]
+		}
+
+		if items[idx].DisplayName == "" {
+			items[idx].DisplayName = strings.TrimSuffix(items[idx].Filename, filepath.Ext(items[idx].Filename))
+		}
+	}
+}
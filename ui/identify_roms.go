@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"mortar/clients"
+	"mortar/models"
+	"mortar/utils"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"qlova.tech/sum"
+)
+
+type IdentifyRomsScreen struct {
+	Platform models.Platform
+}
+
+func InitIdentifyRomsScreen(platform models.Platform) IdentifyRomsScreen {
+	return IdentifyRomsScreen{
+		Platform: platform,
+	}
+}
+
+func (i IdentifyRomsScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.IdentifyRoms
+}
+
+func (i IdentifyRomsScreen) Draw() (value interface{}, exitCode int, e error) {
+	logger := gaba.GetLoggerInstance()
+
+	isRomM := i.Platform.Host.HostType == shared.HostTypes.ROMM
+
+	if i.Platform.DatFile == "" && !isRomM {
+		showTimedMessage(fmt.Sprintf("No DAT file or RomM host for %s", i.Platform.Name))
+		return nil, 404, nil
+	}
+
+	process, err := gaba.ProcessMessage(fmt.Sprintf("Identifying %s ROMs...", i.Platform.Name), gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+		var dat *models.Dat
+		if i.Platform.DatFile != "" {
+			var err error
+			dat, err = utils.LoadDat(i.Platform.DatFile)
+			if err != nil {
+				logger.Error("Unable to load DAT", "dat_file", i.Platform.DatFile, "error", err)
+			}
+		}
+
+		var rommRoms []clients.RomMRom
+		if isRomM {
+			client, err := clients.BuildClient(i.Platform.Host)
+			if err != nil {
+				return nil, err
+			}
+
+			rommRoms, err = client.(*clients.RomMClient).ListRoms(i.Platform.RomMPlatformID)
+			if err != nil {
+				logger.Error("Unable to list RomM ROMs", "error", err)
+			}
+		}
+
+		if dat == nil && len(rommRoms) == 0 {
+			return nil, errors.New("no hash sources available")
+		}
+
+		return utils.IdentifyLocalRoms(i.Platform, dat, rommRoms)
+	})
+	if err != nil {
+		logger.Error("Unable to identify ROMs", "platform", i.Platform.Name, "error", err)
+		showTimedMessage(fmt.Sprintf("Unable to identify %s ROMs", i.Platform.Name))
+		return nil, 404, nil
+	}
+
+	proposals := process.Result.([]models.RenameProposal)
+
+	if len(proposals) == 0 {
+		showTimedMessage("All identified ROMs already have canonical names!")
+		return nil, 404, nil
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, proposal := range proposals {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("%s -> %s", proposal.CurrentName, proposal.ProposedName),
+			Selected: false,
+			Focused:  false,
+			Metadata: proposal,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("Rename %s ROMs", i.Platform.Name), menuItems)
+	options.EnableMultiSelect = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "Select", HelpText: "Multi"},
+		{ButtonName: "A", HelpText: "Rename"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if selection.IsNone() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	var selected []models.RenameProposal
+	for _, item := range selection.Unwrap().SelectedItems {
+		selected = append(selected, item.Metadata.(models.RenameProposal))
+	}
+
+	confirm, err := gaba.ConfirmationMessage(fmt.Sprintf("Rename %d ROMs and their art?", len(selected)), []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Cancel"},
+		{ButtonName: "A", HelpText: "Rename"},
+	}, gaba.MessageOptions{})
+	if err != nil || confirm.IsNone() {
+		return nil, 2, err
+	}
+
+	renamed := 0
+	_, _ = gaba.ProcessMessage(fmt.Sprintf("Renaming %d ROMs...", len(selected)), gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+		for _, proposal := range selected {
+			if err := utils.ApplyRename(i.Platform, proposal); err != nil {
+				logger.Error("Unable to rename ROM", "from", proposal.CurrentName, "to", proposal.ProposedName, "error", err)
+				continue
+			}
+			renamed++
+		}
+		return nil, nil
+	})
+
+	showTimedMessage(fmt.Sprintf("Renamed %d/%d ROMs!", renamed, len(selected)))
+
+	return nil, 0, nil
+}
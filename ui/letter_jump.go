@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"mortar/models"
+	"mortar/state"
+	"mortar/utils"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+)
+
+// LetterJumpScreen lists the letters games start with so a long list can be jumped through. It returns the index of
+// the first game with the chosen letter.
+type LetterJumpScreen struct {
+	Platform     models.Platform
+	SearchFilter string
+	Letters      []utils.LetterEntry
+}
+
+func InitLetterJumpScreen(platform models.Platform, searchFilter string, letters []utils.LetterEntry) LetterJumpScreen {
+	return LetterJumpScreen{
+		Platform:     platform,
+		SearchFilter: searchFilter,
+		Letters:      letters,
+	}
+}
+
+func (l LetterJumpScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.LetterJump
+}
+
+func (l LetterJumpScreen) Draw() (value interface{}, exitCode int, e error) {
+	current := state.GetAppState().LastSelectedIndex
+	selected := 0
+
+	var menuItems []gaba.MenuItem
+	for idx, entry := range l.Letters {
+		if entry.Index <= current {
+			selected = idx
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("%s (%d)", entry.Letter, entry.Count),
+			Selected: false,
+			Focused:  false,
+			Metadata: entry.Index,
+		})
+	}
+
+	options := gaba.DefaultListOptions("Jump to Letter", menuItems)
+	options.SelectedIndex = selected
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Jump"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if selection.IsNone() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	return selection.Unwrap().SelectedItem.Metadata.(int), 0, nil
+}
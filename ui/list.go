@@ -1,120 +1,189 @@
 package ui
 
 import (
-	"encoding/json"
+	"fmt"
 	"mortar/clients"
 	"mortar/models"
 	"mortar/utils"
-	"os"
-	"path"
+	"net/http"
+	"net/url"
 	"path/filepath"
-	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
 	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
 )
 
-func FetchListStateless(platform models.Platform) (shared.Items, error) {
+// FetchListStateless lists a platform's games on its host. RomM also returns what it knows about each game, keyed by
+// ROM ID, and where each letter starts.
+func FetchListStateless(platform models.Platform) (utils.CatalogCacheEntry, error) {
 	logger := gaba.GetLoggerInstance()
 
 	logger.Debug("Fetching Item List",
 		"host", platform.Host)
 
-	client, err := clients.BuildClient(platform.Host)
-	if err != nil {
-		return nil, err
-	}
+	var list utils.CatalogCacheEntry
 
-	defer func(client shared.Client) {
-		err := client.Close()
+	switch platform.Host.HostType {
+	case shared.HostTypes.ROMM:
+		client := clients.NewRomMClient(platform.Host.RootURI, platform.Host.Port, platform.Host.Username, platform.Host.Password)
+
+		roms, err := client.ListRomsWithIndex(platform.RomMPlatformID)
 		if err != nil {
-			logger.Error("Unable to close client", "error", err)
+			return list, err
 		}
-	}(client)
-
-	subdirectory := ""
 
-	switch platform.Host.HostType {
-	case shared.HostTypes.ROMM:
-		subdirectory = platform.RomMPlatformID
+		list.CharIndex = roms.CharIndex
+		list.Metadata = make(models.GameMetadataIndex, len(roms.Items))
+		for _, rom := range roms.Items {
+			item := rom.Item()
+			list.Items = append(list.Items, item)
+			list.Metadata[item.RomID] = rom.Metadata()
+		}
 	default:
-		subdirectory = platform.HostSubdirectory
-	}
+		client, err := clients.BuildClient(platform.Host)
+		if err != nil {
+			return list, err
+		}
 
-	items, err := client.ListDirectory(subdirectory)
-	if err != nil {
-		return nil, err
+		defer func(client shared.Client) {
+			err := client.Close()
+			if err != nil {
+				logger.Error("Unable to close client", "error", err)
+			}
+		}(client)
+
+		list.Items, err = client.ListDirectory(platform.HostSubdirectory)
+		if err != nil {
+			return list, err
+		}
 	}
 
-	for i, item := range items {
-		items[i].DisplayName = strings.ReplaceAll(item.Filename, filepath.Ext(item.Filename), "")
+	for i, item := range list.Items {
+		list.Items[i].DisplayName = strings.ReplaceAll(item.Filename, filepath.Ext(item.Filename), "")
 	}
 
-	filtered := make([]shared.Item, 0, len(items))
-	for _, item := range items {
+	filtered := make([]shared.Item, 0, len(list.Items))
+	for _, item := range list.Items {
 		if !strings.HasPrefix(item.Filename, ".") {
 			filtered = append(filtered, item)
 		}
 	}
-	items = filtered
+	list.Items = filtered
 
-	if platform.Host.HostType == shared.HostTypes.MEGATHREAD {
-		jsonData, err := json.Marshal(items)
-		if err != nil {
-			logger.Debug("Unable to get marshal JSON for Megathread", "error", err)
+	return list, nil
+}
 
-			cwd, err := os.Getwd()
-			if err != nil {
-				logger.Debug("Unable to get current working directory for caching Megathread", "error", err)
-			}
+// searchGames runs a search from the games list, see utils.ParseQuery for what it understands. Free text is ranked
+// by relevance, with RomM games also matched by their alternative names.
+func searchGames(platform models.Platform, items shared.Items, raw string) (shared.Items, error) {
+	query, err := utils.ParseQuery(raw)
+	if err != nil {
+		return nil, err
+	}
 
-			filePath := path.Join(cwd, ".cache", utils.CachedMegaThreadJsonFilename("", ""))
-			err = os.WriteFile(filePath, jsonData, 0644)
-			if err != nil {
-				logger.Debug("Unable to write JSON to file for Megathread", "error", err)
-			}
-		}
+	metadata := utils.CatalogMetadata(platform)
+	if query.NeedsMetadata() && metadata == nil {
+		return nil, fmt.Errorf("genre: only works on RomM hosts")
 	}
 
-	return items, nil
+	return queryGames(query, platform, items, metadata), nil
 }
 
-func filterList(itemList []shared.Item, filters models.Filters) []shared.Item {
-	result := itemList
-
-	if len(filters.InclusiveFilters) > 0 {
-		result = nil
-		for _, item := range itemList {
-			for _, filter := range filters.InclusiveFilters {
-				if strings.Contains(strings.ToLower(item.DisplayName), strings.ToLower(filter)) {
-					result = append(result, item)
-					break
-				}
-			}
+func queryGames(query utils.Query, platform models.Platform, items shared.Items, metadata models.GameMetadataIndex) shared.Items {
+	var installed map[string]bool
+	if query.NeedsInstalled() {
+		installed = utils.InstalledRoms(platform)
+	}
+
+	return query.Apply(items, func(item shared.Item) utils.QueryFacts {
+		return utils.QueryFacts{
+			Metadata:  metadata[item.RomID],
+			Installed: installed != nil && utils.IsRomInstalled(installed, item),
 		}
+	})
+}
+
+// listValidators are the caching headers a host returned for a platform's list.
+type listValidators struct {
+	NotModified  bool
+	ETag         string
+	LastModified string
+}
+
+// revalidateList asks the host whether a platform's list changed since it was cached, using the ETag and
+// Last-Modified headers from the last fetch. Hosts that don't send either just have their list fetched again, and
+// so do RomM hosts, whose API only answers GET and has no validators for the list.
+func revalidateList(platform models.Platform, cached *utils.CatalogCacheEntry) listValidators {
+	logger := gaba.GetLoggerInstance()
+
+	if platform.Host.HostType == shared.HostTypes.ROMM {
+		return listValidators{}
 	}
 
-	if len(filters.ExclusiveFilters) > 0 {
-		filtered := make([]shared.Item, 0, len(result))
-		for _, item := range result {
-			excluded := false
-			for _, filter := range filters.ExclusiveFilters {
-				if strings.Contains(strings.ToLower(item.DisplayName), strings.ToLower(filter)) {
-					excluded = true
-					break
-				}
-			}
-			if !excluded {
-				filtered = append(filtered, item)
-			}
+	root := platform.Host.RootURI
+	if platform.Host.Port != 0 {
+		root = root + ":" + strconv.Itoa(platform.Host.Port)
+	}
+
+	listURL, err := url.JoinPath(root, platform.HostSubdirectory)
+	if err != nil {
+		logger.Debug("Unable to build list URL for revalidation", "platform", platform.Name, "error", err)
+		return listValidators{}
+	}
+
+	req, err := http.NewRequest(http.MethodHead, listURL, nil)
+	if err != nil {
+		return listValidators{}
+	}
+
+	for key, value := range buildDownloadHeaders(platform.Host) {
+		req.Header.Set(key, value)
+	}
+
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
 		}
-		result = filtered
 	}
 
-	slices.SortFunc(result, func(a, b shared.Item) int {
-		return strings.Compare(strings.ToLower(a.DisplayName), strings.ToLower(b.DisplayName))
-	})
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		logger.Debug("Unable to revalidate list", "platform", platform.Name, "error", err)
+		return listValidators{}
+	}
+	defer resp.Body.Close()
+
+	validators := listValidators{
+		NotModified:  resp.StatusCode == http.StatusNotModified,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	if validators.NotModified && cached != nil {
+		if validators.ETag == "" {
+			validators.ETag = cached.ETag
+		}
+		if validators.LastModified == "" {
+			validators.LastModified = cached.LastModified
+		}
+	}
+
+	if resp.StatusCode >= 300 && !validators.NotModified {
+		return listValidators{}
+	}
+
+	return validators
+}
 
-	return result
+// applyPlatformFilters applies the host's and the platform's filter rules and the presets that are on, see
+// utils.PlatformFilterLayers.
+func applyPlatformFilters(platform models.Platform, items shared.Items) shared.Items {
+	return utils.ApplyFilters(items, utils.PlatformFilterLayers(platform))
 }
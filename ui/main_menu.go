@@ -1,12 +1,16 @@
 package ui
 
 import (
+	"fmt"
 	"mortar/models"
+	"mortar/utils"
 
 	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
 	"qlova.tech/sum"
 )
 
+const searchEverythingText = "Search Everything"
+
 type MainMenu struct {
 	Hosts       models.Hosts
 	HostIndices map[string]int
@@ -31,14 +35,34 @@ func (m MainMenu) Name() sum.Int[models.ScreenName] {
 func (m MainMenu) Draw() (host interface{}, exitCode int, e error) {
 	var menuItems []gaba.MenuItem
 	for _, host := range m.Hosts {
+		text := host.DisplayName
+		if !utils.IsHostReachable(host) {
+			text += " [Offline]"
+		}
+
 		menuItems = append(menuItems, gaba.MenuItem{
-			Text:     host.DisplayName,
+			Text:     text,
 			Selected: false,
 			Focused:  false,
 			Metadata: host,
 		})
 	}
 
+	menuItems = append(menuItems, gaba.MenuItem{
+		Text:     searchEverythingText,
+		Selected: false,
+		Focused:  false,
+	})
+
+	if count := len(utils.Wishlist()); count > 0 {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     fmt.Sprintf("%s (%d)", wishlistText, count),
+			Selected: false,
+			Focused:  false,
+			Metadata: wishlistText,
+		})
+	}
+
 	options := gaba.DefaultListOptions("Mortar", menuItems)
 	options.EnableAction = true
 	options.FooterHelpItems = []gaba.FooterHelpItem{
@@ -55,6 +79,12 @@ func (m MainMenu) Draw() (host interface{}, exitCode int, e error) {
 	if selection.IsSome() && selection.Unwrap().ActionTriggered {
 		return models.Host{}, 4, nil
 	} else if selection.IsSome() && !selection.Unwrap().ActionTriggered && selection.Unwrap().SelectedIndex != -1 {
+		if selection.Unwrap().SelectedItem.Metadata == nil {
+			return models.Host{}, 5, nil
+		}
+		if selection.Unwrap().SelectedItem.Metadata == wishlistText {
+			return models.Host{}, 6, nil
+		}
 		return selection.Unwrap().SelectedItem.Metadata.(models.Host), 0, nil
 	}
 
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"mortar/models"
+	"mortar/utils"
+	"path/filepath"
+	"strings"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+)
+
+const (
+	missingFolderCreate = "create"
+	missingFolderChoose = "choose"
+	missingFolderSkip   = "skip"
+)
+
+// ResolveMissingFolders asks what to do with platforms that have no local folder: create one in the NextUI naming
+// format, pick an existing folder or skip the platform for this session. Platforms left unresolved are removed from
+// the running config so the rest of the hosts stay usable, and are back on the next launch.
+func ResolveMissingFolders(config *models.Config, missing models.Platforms, romDirectories shared.Items) {
+	logger := gaba.GetLoggerInstance()
+
+	var items []gaba.ItemWithOptions
+	for _, platform := range missing {
+		items = append(items, gaba.ItemWithOptions{
+			Item: gaba.MenuItem{
+				Text:     platform.Name,
+				Metadata: platform,
+			},
+			Options: []gaba.Option{
+				{DisplayName: "Create " + utils.RomFolderName(platform), Value: missingFolderCreate},
+				{DisplayName: "Choose Folder", Value: missingFolderChoose},
+				{DisplayName: "Skip", Value: missingFolderSkip},
+			},
+		})
+	}
+
+	result, err := gaba.OptionsList(
+		"Missing ROM Folders",
+		items,
+		[]gaba.FooterHelpItem{
+			{ButtonName: "B", HelpText: "Skip All"},
+			{ButtonName: "←→", HelpText: "Cycle"},
+			{ButtonName: "Start", HelpText: "Confirm"},
+		},
+	)
+	if err != nil {
+		logger.Error("Unable to show missing folder options", "error", err)
+	}
+
+	if err == nil && result.IsSome() {
+		items = result.Unwrap().Items
+	} else {
+		for idx := range items {
+			items[idx].SelectedOption = len(items[idx].Options) - 1
+		}
+	}
+
+	directories := make(map[string]string)
+	var skipped []string
+	var failed []string
+
+	for _, item := range items {
+		platform := item.Item.Metadata.(models.Platform)
+
+		var directory string
+
+		switch item.Options[item.SelectedOption].Value {
+		case missingFolderCreate:
+			directory, err = utils.CreateRomFolder(platform)
+			if err != nil {
+				logger.Error("Unable to create ROM folder", "platform", platform.Name, "error", err)
+				failed = append(failed, platform.Name)
+			}
+		case missingFolderChoose:
+			directory = chooseRomFolder(platform, romDirectories)
+		}
+
+		if directory == "" {
+			skipped = append(skipped, platform.Name)
+			continue
+		}
+
+		utils.SetLocalDirectory(config, platform.Name, directory)
+		directories[platform.Name] = directory
+		logger.Debug("Resolved missing ROM folder", "platform", platform.Name, "directory", directory)
+	}
+
+	if len(directories) > 0 {
+		if err := utils.SaveLocalDirectories(config, directories); err != nil {
+			logger.Error("Unable to save local directories", "error", err)
+		}
+	}
+
+	if len(skipped) > 0 {
+		logger.Info("Skipping platforms without local folders for this session", "platforms", skipped)
+		utils.RemovePlatforms(config, skipped)
+	}
+
+	if len(failed) > 0 {
+		_, _ = gaba.ConfirmationMessage(fmt.Sprintf("Unable to create folders for:\n%s\nThey will be skipped.", strings.Join(failed, "\n")),
+			[]gaba.FooterHelpItem{
+				{ButtonName: "A", HelpText: "Continue"},
+			},
+			gaba.MessageOptions{})
+	}
+}
+
+func chooseRomFolder(platform models.Platform, romDirectories shared.Items) string {
+	var menuItems []gaba.MenuItem
+	for _, directory := range romDirectories {
+		if !directory.IsDirectory || strings.HasPrefix(directory.Filename, ".") {
+			continue
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     directory.Filename,
+			Metadata: filepath.Join(utils.GetRomDirectory(), directory.Filename),
+		})
+	}
+
+	if len(menuItems) == 0 {
+		return ""
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("Folder for %s", platform.Name), menuItems)
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Skip"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil || !selection.IsSome() || selection.Unwrap().SelectedIndex == -1 {
+		return ""
+	}
+
+	return selection.Unwrap().SelectedItem.Metadata.(string)
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"mortar/models"
+	"mortar/utils"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+)
+
+// ConfirmHostReachable checks an offline host again before it is opened. When it is still unreachable the user can
+// browse its cached lists read-only, or is told why it can't be opened when there is nothing cached.
+func ConfirmHostReachable(host models.Host) bool {
+	if utils.IsHostReachable(host) {
+		return true
+	}
+
+	_, _ = gaba.ProcessMessage(fmt.Sprintf("Connecting to %s...", host.DisplayName), gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+		return nil, utils.ProbeHost(host)
+	})
+
+	if utils.IsHostReachable(host) {
+		return true
+	}
+
+	gaba.GetLoggerInstance().Error("Host is unreachable", "host", host.DisplayName, "error", utils.HostProbeError(host))
+
+	if !hasCachedLists(host) {
+		_, _ = gaba.ConfirmationMessage(fmt.Sprintf("%s is unreachable.\nCheck your connection and that the server is running.", host.DisplayName),
+			[]gaba.FooterHelpItem{
+				{ButtonName: "B", HelpText: "Back"},
+			},
+			gaba.MessageOptions{})
+		return false
+	}
+
+	result, _ := gaba.ConfirmationMessage(fmt.Sprintf("%s is unreachable.\nBrowse cached lists offline?\nDownloads are unavailable.", host.DisplayName),
+		[]gaba.FooterHelpItem{
+			{ButtonName: "B", HelpText: "Back"},
+			{ButtonName: "A", HelpText: "Browse Offline"},
+		},
+		gaba.MessageOptions{})
+
+	return result.IsSome()
+}
+
+// requireOnline re-checks an offline host before anything is downloaded from it.
+func requireOnline(platform models.Platform) bool {
+	if utils.IsHostReachable(platform.Host) || utils.ProbeHost(platform.Host) == nil {
+		return true
+	}
+
+	_, _ = gaba.ConfirmationMessage(fmt.Sprintf("%s is offline.\nDownloads are unavailable until it can be reached.", platform.Host.DisplayName),
+		[]gaba.FooterHelpItem{
+			{ButtonName: "B", HelpText: "Back"},
+		},
+		gaba.MessageOptions{})
+
+	return false
+}
+
+func hasCachedLists(host models.Host) bool {
+	for _, platform := range host.Platforms {
+		platform.Host = host
+
+		if utils.HasCatalogCache(platform) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"mortar/models"
+	"mortar/utils"
 
 	"github.com/UncleJunVIP/gabagool/pkg/gabagool"
 	"qlova.tech/sum"
@@ -32,14 +33,39 @@ func (ps PlatformSelection) Draw() (p interface{}, exitCode int, e error) {
 	var menuItems []gabagool.MenuItem
 	for _, platform := range ps.Host.Platforms {
 		platform.Host = ps.Host
+
+		// The list can't be redrawn while it is showing, so counts are only added for lists that have already loaded
+		text := platform.Name
+		if status, ok := platformStatus(platform); ok && status.State == prefetchDone {
+			text = fmt.Sprintf("%s (%d)", platform.Name, status.Count)
+		}
+
 		menuItems = append(menuItems, gabagool.MenuItem{
-			Text:     platform.Name,
+			Text:     text,
 			Selected: false,
 			Focused:  false,
 			Metadata: platform,
 		})
 	}
 
+	// With a single host there is no main menu, so the global search and the wishlist live here.
+	if ps.QuitOnBack && len(ps.Host.Platforms) > 1 {
+		menuItems = append(menuItems, gabagool.MenuItem{
+			Text:     searchEverythingText,
+			Selected: false,
+			Focused:  false,
+		})
+	}
+
+	if count := len(utils.Wishlist()); ps.QuitOnBack && count > 0 {
+		menuItems = append(menuItems, gabagool.MenuItem{
+			Text:     fmt.Sprintf("%s (%d)", wishlistText, count),
+			Selected: false,
+			Focused:  false,
+			Metadata: wishlistText,
+		})
+	}
+
 	var fhi []gabagool.FooterHelpItem
 
 	if ps.QuitOnBack {
@@ -61,6 +87,10 @@ func (ps PlatformSelection) Draw() (p interface{}, exitCode int, e error) {
 		title = fmt.Sprintf("Mortar | %s", ps.Host.DisplayName)
 	}
 
+	if !utils.IsHostReachable(ps.Host) {
+		title += " [Offline]"
+	}
+
 	options := gabagool.DefaultListOptions(title, menuItems)
 	options.EnableAction = ps.QuitOnBack
 	options.FooterHelpItems = fhi
@@ -74,6 +104,12 @@ func (ps PlatformSelection) Draw() (p interface{}, exitCode int, e error) {
 	if selection.IsSome() && selection.Unwrap().ActionTriggered && ps.QuitOnBack {
 		return nil, 4, nil
 	} else if selection.IsSome() && selection.Unwrap().SelectedIndex != -1 {
+		if selection.Unwrap().SelectedItem.Metadata == nil {
+			return nil, 5, nil
+		}
+		if selection.Unwrap().SelectedItem.Metadata == wishlistText {
+			return nil, 6, nil
+		}
 		return selection.Unwrap().SelectedItem.Metadata.(models.Platform), 0, nil
 	}
 
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"mortar/models"
+	"sync"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+)
+
+// prefetchWorkers bounds how many platform lists are fetched at once in the background.
+const prefetchWorkers = 3
+
+type prefetchState int
+
+const (
+	prefetchQueued prefetchState = iota
+	prefetchLoading
+	prefetchDone
+	prefetchFailed
+)
+
+type prefetchStatus struct {
+	State prefetchState
+	Count int
+}
+
+var prefetchStatuses sync.Map
+
+// catalogLocks makes a platform opened while it is being prefetched wait for that fetch instead of starting another.
+var catalogLocks sync.Map
+
+// StartPrefetch warms the catalog cache for every platform in the background. Lists still within the TTL are only
+// read from disk, so this mostly fills in the game counts shown in the platform list.
+func StartPrefetch(hosts models.Hosts) {
+	var platforms models.Platforms
+	for _, host := range hosts {
+		for _, platform := range host.Platforms {
+			platform.Host = host
+			platforms = append(platforms, platform)
+			prefetchStatuses.Store(platformKey(platform), prefetchStatus{State: prefetchQueued})
+		}
+	}
+
+	jobs := make(chan models.Platform)
+
+	for i := 0; i < prefetchWorkers; i++ {
+		go func() {
+			for platform := range jobs {
+				prefetchPlatform(platform)
+			}
+		}()
+	}
+
+	go func() {
+		for _, platform := range platforms {
+			jobs <- platform
+		}
+		close(jobs)
+	}()
+}
+
+func prefetchPlatform(platform models.Platform) {
+	logger := gaba.GetLoggerInstance()
+
+	prefetchStatuses.Store(platformKey(platform), prefetchStatus{State: prefetchLoading})
+
+	items, err := loadGamesList(platform, false)
+	if err != nil || items == nil {
+		logger.Debug("Unable to prefetch list", "host", platform.Host.DisplayName, "platform", platform.Name, "error", err)
+		prefetchStatuses.Store(platformKey(platform), prefetchStatus{State: prefetchFailed})
+		return
+	}
+
+	recordGameCount(platform, len(items))
+	logger.Debug("Prefetched list", "host", platform.Host.DisplayName, "platform", platform.Name, "count", len(items))
+}
+
+func recordGameCount(platform models.Platform, count int) {
+	prefetchStatuses.Store(platformKey(platform), prefetchStatus{State: prefetchDone, Count: count})
+}
+
+func platformStatus(platform models.Platform) (prefetchStatus, bool) {
+	status, ok := prefetchStatuses.Load(platformKey(platform))
+	if !ok {
+		return prefetchStatus{}, false
+	}
+
+	return status.(prefetchStatus), true
+}
+
+func lockCatalog(platform models.Platform) func() {
+	lock, _ := catalogLocks.LoadOrStore(platformKey(platform), &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	return lock.(*sync.Mutex).Unlock
+}
+
+func platformKey(platform models.Platform) string {
+	return platform.Host.DisplayName + "|" + platform.Name
+}
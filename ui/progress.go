@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+)
+
+// progressCheckpoint is how long progress is shown before asking whether to keep going.
+const progressCheckpoint = 10 * time.Second
+
+// processWithProgress runs work in the background and redraws the message with a "done/total" count
+// every time work reports that a step has finished. When work is still running after progressCheckpoint, the user is
+// asked whether to keep going and B cancels the context given to work, which should stop starting new steps. It
+// reports whether the work was cancelled.
+func processWithProgress(message string, total int, work func(ctx context.Context, tick func())) bool {
+	var completed atomic.Int64
+	var finished atomic.Bool
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates := make(chan struct{}, 1)
+	done := make(chan struct{})
+
+	go func() {
+		work(ctx, func() {
+			completed.Add(1)
+			select {
+			case updates <- struct{}{}:
+			default:
+			}
+		})
+		close(done)
+	}()
+
+	checkpoint := time.Now().Add(progressCheckpoint)
+
+	for !finished.Load() {
+		if ctx.Err() == nil && !time.Now().Before(checkpoint) {
+			keepGoing, err := gaba.ConfirmationMessage(fmt.Sprintf("%s %d/%d\nKeep going?", message, completed.Load(), total), []gaba.FooterHelpItem{
+				{ButtonName: "B", HelpText: "Cancel"},
+				{ButtonName: "A", HelpText: "Keep Going"},
+			}, gaba.MessageOptions{})
+
+			select {
+			case <-done:
+				// Finished while the question was up, there is nothing left to cancel
+			default:
+				if err != nil || keepGoing.IsNone() {
+					cancel()
+				}
+			}
+
+			checkpoint = time.Now().Add(progressCheckpoint)
+		}
+
+		text := fmt.Sprintf("%s %d/%d", message, completed.Load(), total)
+
+		var checkpointReached <-chan time.Time
+		if ctx.Err() != nil {
+			text = "Cancelling..."
+		} else {
+			checkpointReached = time.After(time.Until(checkpoint))
+		}
+
+		_, _ = gaba.ProcessMessage(text, gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+			select {
+			case <-updates:
+			case <-checkpointReached:
+			case <-done:
+				finished.Store(true)
+			}
+			return nil, nil
+		})
+	}
+
+	return ctx.Err() != nil
+}
+
+func summarizeNames(heading string, names []string, limit int) string {
+	summary := heading
+	for idx, name := range names {
+		if idx == limit {
+			summary += fmt.Sprintf("\n...and %d more", len(names)-limit)
+			break
+		}
+		summary += "\n" + name
+	}
+	return summary
+}
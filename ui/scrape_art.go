@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"mortar/models"
+	"mortar/state"
+	"mortar/utils"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"qlova.tech/sum"
+)
+
+type ScrapeArtScreen struct {
+	Platform models.Platform
+}
+
+func InitScrapeArtScreen(platform models.Platform) ScrapeArtScreen {
+	return ScrapeArtScreen{
+		Platform: platform,
+	}
+}
+
+func (s ScrapeArtScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.ScrapeArt
+}
+
+func (s ScrapeArtScreen) Draw() (value interface{}, exitCode int, e error) {
+	logger := gaba.GetLoggerInstance()
+
+	process, err := gaba.ProcessMessage(fmt.Sprintf("Scanning %s...", s.Platform.Name), gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+		return s.findGamesWithoutArt()
+	})
+	if err != nil {
+		logger.Error("Unable to scan local ROMs", "platform", s.Platform.Name, "error", err)
+		showTimedMessage("Unable to scan local ROMs!")
+		return nil, -1, err
+	}
+
+	games := process.Result.(shared.Items)
+
+	if len(games) == 0 {
+		showTimedMessage("Every game already has art!")
+		return nil, 404, nil
+	}
+
+	confirm, err := gaba.ConfirmationMessage(fmt.Sprintf("%d games are missing art.\nScrape art for them?", len(games)), []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Cancel"},
+		{ButtonName: "A", HelpText: "Scrape"},
+	}, gaba.MessageOptions{})
+	if err != nil || confirm.IsNone() {
+		return nil, 2, err
+	}
+
+	downloadType := state.GetAppState().Config.ArtDownloadType
+
+	saved, missing, cancelled := fetchArt(s.Platform, games, downloadType)
+	if cancelled {
+		return nil, 0, nil
+	}
+
+	if len(saved) == 0 {
+		showTimedMessage("No art found!")
+		return nil, 0, nil
+	}
+
+	showArtSummary(len(saved), len(games), missing)
+
+	review, err := gaba.ConfirmationMessage("Review the art that was found?", []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Keep All"},
+		{ButtonName: "A", HelpText: "Review"},
+	}, gaba.MessageOptions{})
+	if err == nil && review.IsSome() {
+		reviewArt(s.Platform, downloadType, saved)
+	}
+
+	time.Sleep(time.Millisecond * 100)
+
+	return nil, 0, nil
+}
+
+// findGamesWithoutArt lists the launchable ROMs, m3u playlists and grouped game folders that have no art yet.
+// Games on a RomM host are matched to the host listing so their RomM cover can be used.
+func (s ScrapeArtScreen) findGamesWithoutArt() (shared.Items, error) {
+	roms, err := utils.ScanLocalRoms(utils.ResolveLocalDirectory(s.Platform))
+	if err != nil {
+		return nil, err
+	}
+
+	hostGames := make(map[string]shared.Item)
+	if s.Platform.Host.HostType == shared.HostTypes.ROMM {
+		items, err := loadGamesList(s.Platform, false)
+		if err != nil {
+			gaba.GetLoggerInstance().Debug("Unable to load RomM games for art", "error", err)
+		}
+		for _, item := range items {
+			hostGames[strings.ToLower(strings.TrimSuffix(item.Filename, filepath.Ext(item.Filename)))] = item
+		}
+	}
+
+	layout := utils.ActiveMediaLayout()
+
+	var games shared.Items
+	for _, rom := range roms {
+		filename := filepath.Base(rom.Path)
+
+		if len(layout.FindArtFiles(s.Platform, filename)) > 0 {
+			continue
+		}
+
+		game := shared.Item{
+			DisplayName: rom.Name,
+			Filename:    filename,
+		}
+
+		if s.Platform.IsArcade && utils.ArcadeMapping[filename] != "" {
+			game.DisplayName = utils.ArcadeMapping[filename]
+		}
+
+		if hostGame, ok := hostGames[strings.ToLower(rom.Name)]; ok {
+			game.ArtURL = hostGame.ArtURL
+		}
+
+		games = append(games, game)
+	}
+
+	return games, nil
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"mortar/models"
 
 	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
@@ -38,3 +39,12 @@ func (s Search) Draw() (value interface{}, exitCode int, e error) {
 
 	return nil, -1, nil
 }
+
+// showInvalidSearch explains why a search couldn't be run before the keyboard is opened again to fix it.
+func showInvalidSearch(err error) {
+	_, _ = gaba.ConfirmationMessage(fmt.Sprintf("Invalid search\n%s", err),
+		[]gaba.FooterHelpItem{
+			{ButtonName: "A", HelpText: "Edit"},
+		},
+		gaba.MessageOptions{})
+}
@@ -6,6 +6,7 @@ import (
 	"mortar/state"
 	"mortar/utils"
 	"mortar/web"
+	"time"
 
 	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
 	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
@@ -149,6 +150,49 @@ func (s SettingsScreen) Draw() (settings interface{}, exitCode int, e error) {
 		})
 	}
 
+	for _, host := range appState.Config.Hosts {
+		size := utils.CatalogCacheSize(host)
+		if size == 0 {
+			continue
+		}
+
+		items = append(items, gaba.ItemWithOptions{
+			Item: gaba.MenuItem{
+				Text:     fmt.Sprintf("Cached Lists: %s", host.DisplayName),
+				Metadata: host,
+			},
+			Options: []gaba.Option{
+				{
+					DisplayName: utils.FormatSize(size),
+					Value:       "clear",
+					Type:        gaba.OptionTypeClickable,
+				},
+			},
+		})
+	}
+
+	items = append(items, gaba.ItemWithOptions{
+		Item: gaba.MenuItem{
+			Text: "Refresh Art Index",
+		},
+		Options: []gaba.Option{
+			{
+				Type: gaba.OptionTypeClickable,
+			},
+		},
+	})
+
+	items = append(items, gaba.ItemWithOptions{
+		Item: gaba.MenuItem{
+			Text: "Collection Tools",
+		},
+		Options: []gaba.Option{
+			{
+				Type: gaba.OptionTypeClickable,
+			},
+		},
+	})
+
 	items = append(items, gaba.ItemWithOptions{
 		Item: gaba.MenuItem{
 			Text: "Launch Configuration API",
@@ -188,6 +232,21 @@ func (s SettingsScreen) Draw() (settings interface{}, exitCode int, e error) {
 			return result, 404, nil
 		}
 
+		if result.Unwrap().SelectedItem.Item.Text == "Collection Tools" {
+			return result, 5, nil
+		}
+
+		if result.Unwrap().SelectedItem.Item.Text == "Refresh Art Index" {
+			_ = utils.RefreshThumbnailIndexes()
+
+			_, _ = gaba.ProcessMessage("Art index will be refreshed on the next art download!",
+				gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+					time.Sleep(time.Millisecond * 1500)
+					return nil, nil
+				})
+			return result, 404, nil
+		}
+
 		if result.Unwrap().SelectedItem.Item.Text == "Empty Cache" {
 			_ = utils.DeleteCache()
 
@@ -198,6 +257,19 @@ func (s SettingsScreen) Draw() (settings interface{}, exitCode int, e error) {
 			return result, 404, nil
 		}
 
+		if host, ok := result.Unwrap().SelectedItem.Item.Metadata.(models.Host); ok {
+			if err := utils.DeleteCatalogCache(host); err != nil {
+				logger.Error("Unable to clear cached lists", "host", host.DisplayName, "error", err)
+			}
+
+			_, _ = gaba.ProcessMessage(fmt.Sprintf("Cached lists for %s cleared!", host.DisplayName),
+				gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+					time.Sleep(time.Millisecond * 1500)
+					return nil, nil
+				})
+			return result, 404, nil
+		}
+
 		newSettingOptions := result.Unwrap().Items
 
 		for _, option := range newSettingOptions {
@@ -227,7 +299,7 @@ func (s SettingsScreen) Draw() (settings interface{}, exitCode int, e error) {
 			}
 		}
 
-		err := utils.SaveConfig(appState.Config)
+		err := utils.SaveSettings(appState.Config)
 		if err != nil {
 			logger.Error("Error saving config", "error", err)
 			return nil, 0, err
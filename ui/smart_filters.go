@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fmt"
+	"mortar/models"
+	"mortar/state"
+	"mortar/utils"
+	"slices"
+	"strings"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+)
+
+// SmartFiltersScreen lists the searches saved on a platform. Picking one applies it to the games list.
+type SmartFiltersScreen struct {
+	Platform     models.Platform
+	SearchFilter string
+}
+
+func InitSmartFiltersScreen(platform models.Platform, searchFilter string) SmartFiltersScreen {
+	return SmartFiltersScreen{
+		Platform:     platform,
+		SearchFilter: searchFilter,
+	}
+}
+
+func (s SmartFiltersScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.SmartFilters
+}
+
+func (s SmartFiltersScreen) Draw() (value interface{}, exitCode int, e error) {
+	var menuItems []gaba.MenuItem
+	for _, saved := range s.Platform.SavedQueries {
+		text := saved.Name
+		if saved.Name != saved.Query {
+			text = fmt.Sprintf("%s | %s", saved.Name, saved.Query)
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     text,
+			Selected: false,
+			Focused:  false,
+			Metadata: saved,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("%s Smart Filters", s.Platform.Name), menuItems)
+	options.EnableAction = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "X", HelpText: "Delete"},
+		{ButtonName: "A", HelpText: "Apply"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if selection.IsNone() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	saved := selection.Unwrap().SelectedItem.Metadata.(models.SavedQuery)
+
+	if selection.Unwrap().ActionTriggered {
+		result, _ := gaba.ConfirmationMessage(fmt.Sprintf("Delete the smart filter \"%s\"?", saved.Name),
+			[]gaba.FooterHelpItem{
+				{ButtonName: "B", HelpText: "Cancel"},
+				{ButtonName: "A", HelpText: "Delete"},
+			},
+			gaba.MessageOptions{})
+
+		if result.IsNone() {
+			return s.Platform, 404, nil
+		}
+
+		platform, err := utils.SavePlatformSettings(state.GetAppState().Config, s.Platform, func(p *models.Platform) {
+			p.SavedQueries = slices.DeleteFunc(slices.Clone(p.SavedQueries), func(q models.SavedQuery) bool {
+				return q.Name == saved.Name
+			})
+		})
+		if err != nil {
+			gaba.GetLoggerInstance().Error("Unable to delete smart filter", "platform", s.Platform.Name, "error", err)
+		}
+
+		return platform, 4, nil
+	}
+
+	return saved, 0, nil
+}
+
+// SaveSearch asks for a name and keeps the search on the platform as a smart filter. Saving under an existing name
+// replaces it. The platform is returned with the filter added.
+func SaveSearch(platform models.Platform, query string) models.Platform {
+	res, err := gaba.Keyboard(query)
+	if err != nil || res.IsNone() || strings.TrimSpace(res.Unwrap()) == "" {
+		return platform
+	}
+
+	saved := models.SavedQuery{
+		Name:  strings.TrimSpace(res.Unwrap()),
+		Query: query,
+	}
+
+	updated, err := utils.SavePlatformSettings(state.GetAppState().Config, platform, func(p *models.Platform) {
+		if idx := slices.IndexFunc(p.SavedQueries, func(q models.SavedQuery) bool { return q.Name == saved.Name }); idx != -1 {
+			p.SavedQueries[idx] = saved
+			return
+		}
+		p.SavedQueries = append(slices.Clone(p.SavedQueries), saved)
+	})
+	if err != nil {
+		gaba.GetLoggerInstance().Error("Unable to save smart filter", "platform", platform.Name, "error", err)
+	}
+
+	return updated
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"mortar/models"
+	"mortar/state"
+	"mortar/utils"
+	"slices"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+)
+
+// SortGamesScreen picks how a platform's games list is ordered. The choice is saved on the platform.
+type SortGamesScreen struct {
+	Platform     models.Platform
+	SearchFilter string
+}
+
+func InitSortGamesScreen(platform models.Platform, searchFilter string) SortGamesScreen {
+	return SortGamesScreen{
+		Platform:     platform,
+		SearchFilter: searchFilter,
+	}
+}
+
+func (s SortGamesScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.SortGames
+}
+
+func (s SortGamesScreen) Draw() (value interface{}, exitCode int, e error) {
+	keys := utils.GameSortKeysFor(utils.CatalogMetadata(s.Platform))
+
+	var keyOptions []gaba.Option
+	for _, key := range keys {
+		keyOptions = append(keyOptions, gaba.Option{DisplayName: utils.GameSortName(key), Value: key})
+	}
+
+	selectedKey := max(0, slices.Index(keys, s.Platform.Sort.By))
+
+	selectedOrder := 0
+	if s.Platform.Sort.Descending {
+		selectedOrder = 1
+	}
+
+	items := []gaba.ItemWithOptions{
+		{
+			Item:           gaba.MenuItem{Text: "Sort By"},
+			Options:        keyOptions,
+			SelectedOption: selectedKey,
+		},
+		{
+			Item: gaba.MenuItem{Text: "Order"},
+			Options: []gaba.Option{
+				{DisplayName: "Ascending", Value: false},
+				{DisplayName: "Descending", Value: true},
+			},
+			SelectedOption: selectedOrder,
+		},
+	}
+
+	result, err := gaba.OptionsList(
+		fmt.Sprintf("Sort %s", s.Platform.Name),
+		items,
+		[]gaba.FooterHelpItem{
+			{ButtonName: "B", HelpText: "Cancel"},
+			{ButtonName: "←→", HelpText: "Cycle"},
+			{ButtonName: "Start", HelpText: "Save"},
+		},
+	)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if result.IsNone() {
+		return nil, 2, nil
+	}
+
+	var sort models.GameSort
+	for _, item := range result.Unwrap().Items {
+		switch item.Item.Text {
+		case "Sort By":
+			sort.By = item.Options[item.SelectedOption].Value.(string)
+		case "Order":
+			sort.Descending = item.Options[item.SelectedOption].Value.(bool)
+		}
+	}
+
+	if sort.IsDefault() {
+		sort = models.GameSort{}
+	}
+
+	if sort == s.Platform.Sort {
+		return nil, 2, nil
+	}
+
+	platform, err := utils.SavePlatformSettings(state.GetAppState().Config, s.Platform, func(p *models.Platform) {
+		p.Sort = sort
+	})
+	if err != nil {
+		gaba.GetLoggerInstance().Error("Unable to save sort order", "platform", s.Platform.Name, "error", err)
+	}
+
+	return platform, 0, nil
+}
+
+// sortLabel names a sort order for titles, like "Size ↓".
+func sortLabel(sort models.GameSort) string {
+	if sort.Descending {
+		return utils.GameSortName(sort.By) + " ↓"
+	}
+	return utils.GameSortName(sort.By) + " ↑"
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+	"mortar/models"
+	"mortar/state"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"qlova.tech/sum"
+)
+
+type ToolsScreen struct {
+}
+
+func InitToolsScreen() ToolsScreen {
+	return ToolsScreen{}
+}
+
+func (t ToolsScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.Tools
+}
+
+func (t ToolsScreen) Draw() (value interface{}, exitCode int, e error) {
+	tools := []struct {
+		name string
+		tool sum.Int[models.Tool]
+	}{
+		{"Verify Collection", models.Tools.VerifyCollection},
+		{"Identify & Rename ROMs", models.Tools.IdentifyRoms},
+		{"Scrape Missing Art", models.Tools.ScrapeArt},
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, tool := range tools {
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     tool.name,
+			Selected: false,
+			Focused:  false,
+			Metadata: tool.tool,
+		})
+	}
+
+	for {
+		options := gaba.DefaultListOptions("Collection Tools", menuItems)
+		options.FooterHelpItems = []gaba.FooterHelpItem{
+			{ButtonName: "B", HelpText: "Back"},
+			{ButtonName: "A", HelpText: "Select"},
+		}
+
+		selection, err := gaba.List(options)
+		if err != nil {
+			return nil, -1, err
+		}
+
+		if selection.IsNone() || selection.Unwrap().SelectedIndex == -1 {
+			return nil, 2, nil
+		}
+
+		tool := selection.Unwrap().SelectedItem.Metadata.(sum.Int[models.Tool])
+
+		platform, ok, err := selectToolPlatform(selection.Unwrap().SelectedItem.Text)
+		if err != nil {
+			return nil, -1, err
+		}
+
+		if ok {
+			return models.ToolSelection{Tool: tool, Platform: platform}, 0, nil
+		}
+	}
+}
+
+func selectToolPlatform(title string) (models.Platform, bool, error) {
+	hosts := state.GetAppState().Config.Hosts
+
+	var menuItems []gaba.MenuItem
+	for _, host := range hosts {
+		for _, platform := range host.Platforms {
+			platform.Host = host
+
+			text := platform.Name
+			if len(hosts) > 1 {
+				text = fmt.Sprintf("%s | %s", host.DisplayName, platform.Name)
+			}
+
+			menuItems = append(menuItems, gaba.MenuItem{
+				Text:     text,
+				Selected: false,
+				Focused:  false,
+				Metadata: platform,
+			})
+		}
+	}
+
+	if len(menuItems) == 0 {
+		return models.Platform{}, false, nil
+	}
+
+	options := gaba.DefaultListOptions(title, menuItems)
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Select"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return models.Platform{}, false, err
+	}
+
+	if selection.IsSome() && selection.Unwrap().SelectedIndex != -1 {
+		return selection.Unwrap().SelectedItem.Metadata.(models.Platform), true, nil
+	}
+
+	return models.Platform{}, false, nil
+}
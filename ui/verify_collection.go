@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"fmt"
+	"mortar/models"
+	"mortar/utils"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"qlova.tech/sum"
+)
+
+type VerifyCollectionScreen struct {
+	Platform models.Platform
+}
+
+func InitVerifyCollectionScreen(platform models.Platform) VerifyCollectionScreen {
+	return VerifyCollectionScreen{
+		Platform: platform,
+	}
+}
+
+func (v VerifyCollectionScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.VerifyCollection
+}
+
+func (v VerifyCollectionScreen) Draw() (value interface{}, exitCode int, e error) {
+	logger := gaba.GetLoggerInstance()
+
+	if v.Platform.DatFile == "" {
+		showTimedMessage(fmt.Sprintf("No DAT file configured for %s", v.Platform.Name))
+		return nil, 404, nil
+	}
+
+	process, err := gaba.ProcessMessage(fmt.Sprintf("Verifying %s...", v.Platform.Name), gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+		dat, err := utils.LoadDat(v.Platform.DatFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return utils.VerifyPlatform(v.Platform, dat)
+	})
+	if err != nil {
+		logger.Error("Unable to verify collection", "platform", v.Platform.Name, "error", err)
+		showTimedMessage(fmt.Sprintf("Unable to verify %s", v.Platform.Name))
+		return nil, 404, nil
+	}
+
+	report := process.Result.(models.VerificationReport)
+
+	available := make(map[string]shared.Item)
+	if len(report.Missing) > 0 {
+		_, _ = gaba.ProcessMessage(fmt.Sprintf("Checking %s for missing games...", v.Platform.Host.DisplayName), gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+			items, err := loadGamesList(v.Platform, false)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, item := range items {
+				available[strings.ToLower(strings.TrimSuffix(item.Filename, filepath.Ext(item.Filename)))] = item
+			}
+
+			return nil, nil
+		})
+	}
+
+	var missingItems []gaba.MenuItem
+	var otherItems []gaba.MenuItem
+
+	for _, game := range report.Missing {
+		item, ok := available[strings.ToLower(game.Name)]
+		if !ok {
+			otherItems = append(otherItems, gaba.MenuItem{Text: "[Missing] " + game.Name})
+			continue
+		}
+
+		item.DisplayName = strings.TrimSuffix(item.Filename, filepath.Ext(item.Filename))
+		missingItems = append(missingItems, gaba.MenuItem{
+			Text:     "[Download] " + game.Name,
+			Selected: false,
+			Focused:  false,
+			Metadata: item,
+		})
+	}
+
+	for _, rom := range report.BadDumps {
+		otherItems = append(otherItems, gaba.MenuItem{Text: "[Bad] " + filepath.Base(rom.LocalPath)})
+	}
+
+	for _, rom := range report.Misnamed {
+		otherItems = append(otherItems, gaba.MenuItem{Text: fmt.Sprintf("[Misnamed] %s -> %s", filepath.Base(rom.LocalPath), rom.ExpectedName)})
+	}
+
+	for _, rom := range report.HashMismatches {
+		otherItems = append(otherItems, gaba.MenuItem{Text: "[Hash Mismatch] " + filepath.Base(rom.LocalPath)})
+	}
+
+	for _, path := range report.Unknown {
+		otherItems = append(otherItems, gaba.MenuItem{Text: "[Unknown] " + filepath.Base(path)})
+	}
+
+	for _, rom := range report.Verified {
+		otherItems = append(otherItems, gaba.MenuItem{Text: "[OK] " + filepath.Base(rom.LocalPath)})
+	}
+
+	summary := fmt.Sprintf("%s\nVerified: %d | Bad: %d | Misnamed: %d\nHash Mismatch: %d | Unknown: %d\nMissing: %d (%d on host)",
+		v.Platform.Name,
+		len(report.Verified), len(report.BadDumps), len(report.Misnamed),
+		len(report.HashMismatches), len(report.Unknown), len(report.Missing), len(missingItems))
+
+	result, err := gaba.ConfirmationMessage(summary, []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "A", HelpText: "Details"},
+	}, gaba.MessageOptions{})
+	if err != nil || result.IsNone() {
+		return nil, 2, err
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("%s Collection", v.Platform.Name), append(missingItems, otherItems...))
+	options.EnableMultiSelect = len(missingItems) > 0
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "Select", HelpText: "Multi"},
+		{ButtonName: "A", HelpText: "Download"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if selection.IsNone() || selection.Unwrap().SelectedIndex == -1 {
+		return nil, 2, nil
+	}
+
+	var selections shared.Items
+	for _, item := range selection.Unwrap().SelectedItems {
+		if game, ok := item.Metadata.(shared.Item); ok {
+			selections = append(selections, game)
+		}
+	}
+
+	if len(selections) == 0 {
+		return nil, 404, nil
+	}
+
+	return selections, 0, nil
+}
+
+func showTimedMessage(message string) {
+	gaba.ProcessMessage(message, gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+		time.Sleep(time.Second * 2)
+		return nil, nil
+	})
+}
@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"mortar/models"
+	"mortar/state"
+	"mortar/utils"
+	"time"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"qlova.tech/sum"
+)
+
+const wishlistText = "Wishlist"
+
+// WishlistScreen lists the starred games of every host so they can be downloaded together once the hosts are
+// reachable. Games are taken off the wishlist once they have been downloaded.
+type WishlistScreen struct {
+}
+
+func InitWishlistScreen() WishlistScreen {
+	return WishlistScreen{}
+}
+
+func (w WishlistScreen) Name() sum.Int[models.ScreenName] {
+	return models.ScreenNames.Wishlist
+}
+
+func (w WishlistScreen) Draw() (value interface{}, exitCode int, e error) {
+	results := wishlistResults()
+
+	if len(results) == 0 {
+		_, _ = gaba.ProcessMessage("The wishlist is empty.\nAdd games from a games list with X.",
+			gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+				time.Sleep(time.Second * 2)
+				return nil, nil
+			})
+		return nil, 404, nil
+	}
+
+	var menuItems []gaba.MenuItem
+	for _, result := range results {
+		text := fmt.Sprintf("%s | %s", result.Platform.Name, result.Item.DisplayName)
+		if !utils.IsHostReachable(result.Platform.Host) {
+			text += " [Offline]"
+		}
+
+		menuItems = append(menuItems, gaba.MenuItem{
+			Text:     text,
+			Selected: false,
+			Focused:  false,
+			Metadata: result,
+		})
+	}
+
+	options := gaba.DefaultListOptions(fmt.Sprintf("Wishlist (%d)", len(results)), menuItems)
+	options.EnableAction = true
+	options.EnableMultiSelect = true
+	options.FooterHelpItems = []gaba.FooterHelpItem{
+		{ButtonName: "B", HelpText: "Back"},
+		{ButtonName: "X", HelpText: "Download All"},
+		{ButtonName: "Select", HelpText: "Multi"},
+		{ButtonName: "A", HelpText: "Download"},
+	}
+
+	selection, err := gaba.List(options)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if selection.IsNone() {
+		return nil, 2, nil
+	}
+
+	var selected models.SearchResults
+
+	if selection.Unwrap().ActionTriggered {
+		for _, result := range results {
+			if utils.IsHostReachable(result.Platform.Host) {
+				selected = append(selected, result)
+			}
+		}
+
+		if len(selected) == 0 {
+			_, _ = gaba.ConfirmationMessage("None of the hosts on the wishlist are reachable.\nTry again once you are online.",
+				[]gaba.FooterHelpItem{
+					{ButtonName: "B", HelpText: "Back"},
+				},
+				gaba.MessageOptions{})
+			return nil, 404, nil
+		}
+	} else if selection.Unwrap().SelectedIndex != -1 {
+		for _, item := range selection.Unwrap().SelectedItems {
+			selected = append(selected, item.Metadata.(models.SearchResult))
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, 2, nil
+	}
+
+	return models.SearchSelection{Results: results, Selected: selected, FromWishlist: true}, 0, nil
+}
+
+// wishlistResults pairs each starred game with its platform in the current config. Games of platforms that are no
+// longer configured are left on the wishlist but not shown.
+func wishlistResults() models.SearchResults {
+	config := state.GetAppState().Config
+
+	var results models.SearchResults
+	for _, entry := range utils.Wishlist() {
+		platform, ok := findPlatform(config, entry.Host, entry.Platform)
+		if !ok {
+			gaba.GetLoggerInstance().Debug("Wishlisted game's platform is not configured", "host", entry.Host, "platform", entry.Platform, "game", entry.Item.Filename)
+			continue
+		}
+
+		results = append(results, models.SearchResult{Platform: platform, Item: entry.Item})
+	}
+
+	return results
+}
+
+func findPlatform(config *models.Config, hostName, platformName string) (models.Platform, bool) {
+	for _, host := range config.Hosts {
+		if host.DisplayName != hostName {
+			continue
+		}
+
+		for _, platform := range host.Platforms {
+			if platform.Name == platformName {
+				platform.Host = host
+				return platform, true
+			}
+		}
+	}
+
+	return models.Platform{}, false
+}
+
+// ToggleWishlist stars or unstars games from the games list and says which it did.
+func ToggleWishlist(platform models.Platform, games shared.Items) {
+	starred, err := utils.ToggleWishlist(platform, games)
+	if err != nil {
+		gaba.GetLoggerInstance().Error("Unable to save wishlist", "error", err)
+	}
+
+	subject := games[0].DisplayName
+	if len(games) > 1 {
+		subject = fmt.Sprintf("%d games", len(games))
+	}
+
+	message := fmt.Sprintf("%s removed from the wishlist", subject)
+	if starred {
+		message = fmt.Sprintf("%s added to the wishlist", subject)
+	}
+
+	_, _ = gaba.ProcessMessage(message, gaba.ProcessMessageOptions{ShowThemeBackground: true}, func() (interface{}, error) {
+		time.Sleep(time.Millisecond * 1000)
+		return nil, nil
+	})
+}
+
+// RemoveDownloadedFromWishlist takes downloaded games off the wishlist.
+func RemoveDownloadedFromWishlist(downloaded models.SearchResults) {
+	var entries models.Wishlist
+	for _, result := range downloaded {
+		entries = append(entries, models.WishlistEntry{
+			Host:     result.Platform.Host.DisplayName,
+			Platform: result.Platform.Name,
+			Item:     result.Item,
+		})
+	}
+
+	if err := utils.RemoveFromWishlist(entries); err != nil {
+		gaba.GetLoggerInstance().Error("Unable to update wishlist", "error", err)
+	}
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"mortar/models"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ArcadeCatalog indexes a MAME or FBNeo DAT by set name.
+type ArcadeCatalog struct {
+	Dat   *models.Dat
+	games map[string]*models.DatGame
+}
+
+// arcadeCatalogs keeps parsed DATs for the session, full MAME DATs take a while to parse.
+var arcadeCatalogs sync.Map
+
+func NewArcadeCatalog(dat *models.Dat) *ArcadeCatalog {
+	catalog := &ArcadeCatalog{
+		Dat:   dat,
+		games: make(map[string]*models.DatGame, len(dat.Games)),
+	}
+
+	for idx := range dat.Games {
+		catalog.games[strings.ToLower(dat.Games[idx].Name)] = &dat.Games[idx]
+	}
+
+	return catalog
+}
+
+func LoadArcadeCatalog(datFile string) (*ArcadeCatalog, error) {
+	if cached, ok := arcadeCatalogs.Load(datFile); ok {
+		return cached.(*ArcadeCatalog), nil
+	}
+
+	dat, err := LoadDat(datFile)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := NewArcadeCatalog(dat)
+	arcadeCatalogs.Store(datFile, catalog)
+
+	return catalog, nil
+}
+
+func IsArcadeCatalogLoaded(datFile string) bool {
+	_, ok := arcadeCatalogs.Load(datFile)
+	return ok
+}
+
+// Game looks up the set for a ROM filename such as sf2.zip.
+func (c *ArcadeCatalog) Game(filename string) (*models.DatGame, bool) {
+	game, ok := c.games[strings.ToLower(strings.TrimSuffix(filename, filepath.Ext(filename)))]
+	return game, ok
+}
+
+// Title is the set's description, falling back to the parent's for clones without one.
+func (c *ArcadeCatalog) Title(filename string) string {
+	game, ok := c.Game(filename)
+	if !ok {
+		return ""
+	}
+
+	if game.Description == "" && game.IsClone() {
+		if parent, ok := c.games[strings.ToLower(game.CloneOf)]; ok {
+			return parent.Description
+		}
+	}
+
+	return game.Description
+}
+
+func (c *ArcadeCatalog) IsHidden(filename string, filters models.ArcadeFilters) bool {
+	game, ok := c.Game(filename)
+	if !ok {
+		return false
+	}
+
+	return (filters.HideClones && game.IsClone()) ||
+		(filters.HideBootlegs && game.IsBootleg()) ||
+		(filters.HideBios && (game.IsBios || game.IsDevice)) ||
+		(filters.HideMechanical && game.IsMechanical)
+}
+
+// Dependencies lists the zips a set needs next to it to run: its BIOS, device ROMs and, for clones in split sets,
+// the parent. Dependencies of dependencies are included.
+func (c *ArcadeCatalog) Dependencies(filename string) []string {
+	game, ok := c.Game(filename)
+	if !ok {
+		return nil
+	}
+
+	added := make(map[string]bool)
+	visited := make(map[string]bool)
+	var dependencies []string
+
+	var visit func(game *models.DatGame)
+
+	add := func(dependency *models.DatGame) {
+		key := strings.ToLower(dependency.Name)
+		if !added[key] && !strings.EqualFold(dependency.Name, game.Name) {
+			added[key] = true
+			dependencies = append(dependencies, dependency.Name+".zip")
+		}
+		visit(dependency)
+	}
+
+	visit = func(current *models.DatGame) {
+		key := strings.ToLower(current.Name)
+		if visited[key] {
+			return
+		}
+		visited[key] = true
+
+		if romOf, ok := c.games[strings.ToLower(current.RomOf)]; ok && current.RomOf != "" {
+			if romOf.IsBios || current.NeedsParent() {
+				add(romOf)
+			} else {
+				// A non-merged clone doesn't need its parent but still needs the parent's BIOS.
+				visit(romOf)
+			}
+		}
+
+		for _, ref := range current.DeviceRefs {
+			if device, ok := c.games[strings.ToLower(ref)]; ok && len(device.Roms) > 0 {
+				add(device)
+			}
+		}
+	}
+
+	visit(game)
+
+	return dependencies
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"mortar/models"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const DefaultArcadeRomset = "Default"
+
+// ArcadeRomset is the set list an arcade platform's core expects. It is read from a MAME / FBNeo DAT, which also
+// provides the arcade catalog, or from a tab separated mapping file like resources/arcade_mapping.txt.
+type ArcadeRomset struct {
+	Name    string
+	Catalog *ArcadeCatalog
+	Mapping map[string]string
+
+	// Chosen is false for the built-in mapping, which is only used for display names.
+	Chosen bool
+}
+
+var arcadeMappings sync.Map
+
+// ArcadeRomsetFile is the romset file configured for a platform. arcade_romset wins over dat_file.
+func ArcadeRomsetFile(platform models.Platform) string {
+	if platform.ArcadeRomset != "" {
+		return platform.ArcadeRomset
+	}
+
+	return platform.DatFile
+}
+
+func IsArcadeRomsetLoaded(platform models.Platform) bool {
+	file := ArcadeRomsetFile(platform)
+	if file == "" {
+		return true
+	}
+
+	if isArcadeMappingFile(file) {
+		_, ok := arcadeMappings.Load(file)
+		return ok
+	}
+
+	return IsArcadeCatalogLoaded(file)
+}
+
+func LoadArcadeRomset(platform models.Platform) (*ArcadeRomset, error) {
+	file := ArcadeRomsetFile(platform)
+
+	if file == "" {
+		return &ArcadeRomset{Name: DefaultArcadeRomset, Mapping: ArcadeMapping}, nil
+	}
+
+	romset := &ArcadeRomset{
+		Name:   strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)),
+		Chosen: true,
+	}
+
+	if isArcadeMappingFile(file) {
+		if cached, ok := arcadeMappings.Load(file); ok {
+			romset.Mapping = cached.(map[string]string)
+			return romset, nil
+		}
+
+		mapping, err := LoadArcadeMapping(ResolveDatPath(file))
+		if err != nil {
+			return nil, err
+		}
+
+		arcadeMappings.Store(file, mapping)
+		romset.Mapping = mapping
+
+		return romset, nil
+	}
+
+	catalog, err := LoadArcadeCatalog(file)
+	if err != nil {
+		return nil, err
+	}
+
+	romset.Catalog = catalog
+	if catalog.Dat.Name != "" {
+		romset.Name = catalog.Dat.Name
+	}
+
+	return romset, nil
+}
+
+func (r *ArcadeRomset) Title(filename string) string {
+	if r.Catalog != nil {
+		return r.Catalog.Title(filename)
+	}
+
+	return r.Mapping[filename]
+}
+
+// Contains reports whether a set from the host is part of the romset.
+func (r *ArcadeRomset) Contains(filename string) bool {
+	if r.Catalog != nil {
+		_, ok := r.Catalog.Game(filename)
+		return ok
+	}
+
+	_, ok := r.Mapping[filename]
+	return ok
+}
+
+func isArcadeMappingFile(file string) bool {
+	return strings.EqualFold(filepath.Ext(file), ".txt")
+}
@@ -0,0 +1,250 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mortar/models"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"qlova.tech/sum"
+)
+
+const LibretroThumbnailRoot = "https://thumbnails.libretro.com"
+
+// ArtWorkers bounds how many art lookups run at the same time.
+const ArtWorkers = 4
+
+var ErrNoArtFound = errors.New("no art found")
+
+// ResolvedArt is where a game's art can be fetched from and where it should be saved.
+// SourcePath is set instead of URL when the art already exists on the SD card.
+type ResolvedArt struct {
+	Game       shared.Item
+	Provider   string
+	URL        string
+	Headers    map[string]string
+	SourcePath string
+	Location   string
+	Err        error
+}
+
+// ResolveArt walks the platform's art provider chain and returns the best candidate from the first provider
+// that has one.
+func ResolveArt(platform models.Platform, game shared.Item, downloadType sum.Int[shared.ArtDownloadType]) ResolvedArt {
+	logger := gaba.GetLoggerInstance()
+
+	for _, provider := range ArtProviderChain(platform, downloadType) {
+		candidates, err := provider.Candidates(platform, game, 1)
+		if err != nil {
+			logger.Debug("Art provider failed", "provider", provider.Name(), "game", game.DisplayName, "error", err)
+			continue
+		}
+
+		if len(candidates) > 0 {
+			return ResolveCandidate(platform, game, candidates[0])
+		}
+	}
+
+	return ResolvedArt{Game: game, Err: ErrNoArtFound}
+}
+
+// ResolveCandidate places a candidate where the active media layout expects it.
+func ResolveCandidate(platform models.Platform, game shared.Item, candidate ArtCandidate) ResolvedArt {
+	return ResolvedArt{
+		Game:       game,
+		Provider:   candidate.Provider,
+		URL:        candidate.URL,
+		Headers:    candidate.Headers,
+		SourcePath: candidate.SourcePath,
+		Location:   ActiveMediaLayout().ArtPath(platform, game.Filename),
+	}
+}
+
+// FindArtCandidates collects the best scoring candidates from every provider the picker offers so the user can
+// choose between them.
+func FindArtCandidates(platform models.Platform, game shared.Item, downloadType sum.Int[shared.ArtDownloadType], limit int) []ArtCandidate {
+	logger := gaba.GetLoggerInstance()
+
+	var candidates []ArtCandidate
+	for _, provider := range ArtPickerProviders(platform, downloadType) {
+		providerCandidates, err := provider.Candidates(platform, game, limit)
+		if err != nil {
+			logger.Debug("Art provider failed", "provider", provider.Name(), "game", game.DisplayName, "error", err)
+			continue
+		}
+		candidates = append(candidates, providerCandidates...)
+	}
+
+	logger.Debug("Ranked art candidates", "game", game.DisplayName, "candidates", candidates)
+
+	return candidates
+}
+
+// FindArt resolves and saves art for a single game, returning the saved path or an empty string.
+func FindArt(platform models.Platform, game shared.Item, downloadType sum.Int[shared.ArtDownloadType]) string {
+	resolved := ResolveArt(platform, game, downloadType)
+	if resolved.Err != nil {
+		return ""
+	}
+
+	artPath, err := FetchArt(platform, resolved)
+	if err != nil {
+		gaba.GetLoggerInstance().Error("Unable to fetch art", "game", game.DisplayName, "error", err)
+		return ""
+	}
+
+	return artPath
+}
+
+// FetchArt downloads or copies resolved art to its location and post-processes it.
+func FetchArt(platform models.Platform, resolved ResolvedArt) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(resolved.Location), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	var err error
+	if resolved.SourcePath != "" {
+		err = copyFile(resolved.SourcePath, resolved.Location)
+	} else {
+		err = downloadFile(resolved.URL, resolved.Location, resolved.Headers)
+	}
+
+	if err != nil {
+		common.DeleteFile(resolved.Location)
+		return "", err
+	}
+
+	if err := ProcessArt(platform, resolved.Game, resolved.Location); err != nil {
+		return "", err
+	}
+
+	return resolved.Location, nil
+}
+
+func downloadFile(sourceURL, destination string, headers map[string]string) error {
+	httpClient := &http.Client{
+		Timeout: 60 * time.Second,
+	}
+
+	req, err := http.NewRequest("GET", sourceURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build download request: %w", err)
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download file: %s", resp.Status)
+	}
+
+	f, err := os.Create(destination)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+
+	return nil
+}
+
+// MoveFile renames a file, copying it instead when the destination is on another filesystem, like the cache and
+// the SD card can be.
+func MoveFile(source, destination string) error {
+	err := os.Rename(source, destination)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	if err := copyFile(source, destination); err != nil {
+		common.DeleteFile(destination)
+		return err
+	}
+
+	return os.Remove(source)
+}
+
+func copyFile(source, destination string) error {
+	src, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, src)
+	return err
+}
+
+// ResolveArtBatch looks up art for every game with a bounded pool of workers.
+// onResolved is called from the workers each time a lookup finishes. Once ctx is cancelled no more lookups are
+// started, the games that were not looked up get its error and it is returned once the running lookups finish.
+func ResolveArtBatch(ctx context.Context, platform models.Platform, games shared.Items, downloadType sum.Int[shared.ArtDownloadType], onResolved func()) ([]ResolvedArt, error) {
+	results := make([]ResolvedArt, len(games))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for range min(ArtWorkers, len(games)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = ResolveArt(platform, games[idx], downloadType)
+				if onResolved != nil {
+					onResolved()
+				}
+			}
+		}()
+	}
+
+	queued := 0
+
+queue:
+	for queued < len(games) {
+		select {
+		case jobs <- queued:
+			queued++
+		case <-ctx.Done():
+			break queue
+		}
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	for idx := queued; idx < len(games); idx++ {
+		results[idx] = ResolvedArt{Game: games[idx], Err: ctx.Err()}
+	}
+
+	return results, ctx.Err()
+}
+
+func ArtDirectory(platform models.Platform) string {
+	return ActiveMediaLayout().ArtDirectory(platform)
+}
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+)
+
+const thumbnailIndexTTL = 7 * 24 * time.Hour
+
+const thumbnailIndexPrefix = "thumbnails_"
+
+type thumbnailIndex struct {
+	FetchedAt time.Time    `json:"fetched_at"`
+	Items     shared.Items `json:"items"`
+}
+
+// thumbnailIndexes keeps the listings loaded this session so a batch of art lookups only reads the cache once.
+var thumbnailIndexes sync.Map
+
+func CacheDirectory() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ".cache"
+	}
+
+	return filepath.Join(cwd, ".cache")
+}
+
+func cachedThumbnailIndexFilename(hostSubdirectory string) string {
+	name := strings.Trim(hostSubdirectory, "/")
+	name = strings.NewReplacer("/", "_", " ", "", "%20", "").Replace(name)
+	return thumbnailIndexPrefix + name + ".json"
+}
+
+// ListThumbnailsCached returns the Libretro thumbnail listing for a system and art type from the cache,
+// fetching it when the cached copy has expired. A stale listing is used when the fetch fails so art can
+// still be matched offline.
+func ListThumbnailsCached(hostSubdirectory string, fetch func() (shared.Items, error)) (shared.Items, error) {
+	logger := gaba.GetLoggerInstance()
+
+	if items, ok := thumbnailIndexes.Load(hostSubdirectory); ok {
+		return items.(shared.Items), nil
+	}
+
+	cachePath := filepath.Join(CacheDirectory(), cachedThumbnailIndexFilename(hostSubdirectory))
+
+	var cached thumbnailIndex
+	hasCache := false
+
+	if data, err := os.ReadFile(cachePath); err == nil {
+		if err := json.Unmarshal(data, &cached); err != nil {
+			logger.Debug("Unable to unmarshal cached thumbnail index", "error", err)
+		} else {
+			hasCache = true
+		}
+	}
+
+	if hasCache && time.Since(cached.FetchedAt) < thumbnailIndexTTL {
+		thumbnailIndexes.Store(hostSubdirectory, cached.Items)
+		return cached.Items, nil
+	}
+
+	items, err := fetch()
+	if err != nil {
+		if hasCache {
+			logger.Debug("Unable to refresh thumbnail index, using stale cache", "subdirectory", hostSubdirectory, "error", err)
+			thumbnailIndexes.Store(hostSubdirectory, cached.Items)
+			return cached.Items, nil
+		}
+		return nil, err
+	}
+
+	thumbnailIndexes.Store(hostSubdirectory, items)
+
+	jsonData, err := json.Marshal(thumbnailIndex{FetchedAt: time.Now(), Items: items})
+	if err != nil {
+		logger.Debug("Unable to marshal thumbnail index", "error", err)
+		return items, nil
+	}
+
+	if err := os.MkdirAll(CacheDirectory(), 0755); err != nil {
+		logger.Debug("Unable to make cache directory", "error", err)
+		return items, nil
+	}
+
+	if err := os.WriteFile(cachePath, jsonData, 0644); err != nil {
+		logger.Debug("Unable to write thumbnail index", "error", err)
+	}
+
+	return items, nil
+}
+
+// RefreshThumbnailIndexes drops every cached thumbnail listing so the next art lookup fetches a fresh copy.
+func RefreshThumbnailIndexes() error {
+	thumbnailIndexes.Clear()
+
+	entries, err := os.ReadDir(CacheDirectory())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), thumbnailIndexPrefix) {
+			if err := os.Remove(filepath.Join(CacheDirectory(), entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	gaba.GetLoggerInstance().Debug("Thumbnail indexes cleared")
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+)
+
+// ArtMatchThreshold is the minimum title similarity for a thumbnail to be considered a match.
+const ArtMatchThreshold = 0.75
+
+const DefaultArtCandidateLimit = 5
+
+type ArtCandidate struct {
+	Provider         string
+	Filename         string
+	HostSubdirectory string
+	URL              string
+	Headers          map[string]string
+	SourcePath       string
+	Score            float64
+}
+
+var regionPreference = []string{"usa", "world", "europe", "japan"}
+
+var regionTags = map[string]bool{
+	"usa": true, "world": true, "europe": true, "japan": true, "asia": true, "australia": true,
+	"brazil": true, "canada": true, "china": true, "france": true, "germany": true, "italy": true,
+	"korea": true, "netherlands": true, "spain": true, "sweden": true, "uk": true,
+}
+
+var developmentTags = []string{"beta", "proto", "demo", "sample", "kiosk", "pirate", "unl"}
+
+// Libretro replaces these characters with an underscore in thumbnail filenames.
+var libretroReplacer = strings.NewReplacer(
+	"&", "_", "*", "_", "/", "_", ":", "_", "`", "_",
+	"<", "_", ">", "_", "?", "_", "\\", "_", "|", "_", "\"", "_",
+)
+
+func LibretroThumbnailName(name string) string {
+	return libretroReplacer.Replace(name)
+}
+
+type parsedTitle struct {
+	tokens []string
+	tags   []string
+}
+
+// parseTitle splits a No-Intro style name into normalized title tokens and lower-cased tags.
+func parseTitle(name string) parsedTitle {
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+
+	title := name
+	if idx := strings.IndexAny(name, "(["); idx != -1 {
+		title = name[:idx]
+	}
+
+	var tags []string
+	depth := 0
+	var current strings.Builder
+	for _, r := range name {
+		switch {
+		case r == '(' || r == '[':
+			depth++
+			current.Reset()
+		case (r == ')' || r == ']') && depth > 0:
+			depth--
+			for _, tag := range strings.Split(current.String(), ",") {
+				if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+					tags = append(tags, tag)
+				}
+			}
+		case depth > 0:
+			current.WriteRune(r)
+		}
+	}
+
+	return parsedTitle{
+		tokens: titleTokens(title),
+		tags:   tags,
+	}
+}
+
+func titleTokens(title string) []string {
+	title = strings.ReplaceAll(strings.ToLower(title), "&", " and ")
+	title = strings.ReplaceAll(title, "_", " and ")
+
+	fields := strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		switch field {
+		case "the", "a", "an", "and":
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+
+	return tokens
+}
+
+// titleSimilarity is the Sørensen–Dice coefficient of the two token sets.
+func titleSimilarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	if slices.Equal(a, b) {
+		return 1
+	}
+
+	counts := make(map[string]int)
+	for _, token := range a {
+		counts[token]++
+	}
+
+	common := 0
+	for _, token := range b {
+		if counts[token] > 0 {
+			counts[token]--
+			common++
+		}
+	}
+
+	return 2 * float64(common) / float64(len(a)+len(b))
+}
+
+func tagBonus(game, candidate parsedTitle) float64 {
+	bonus := 0.0
+
+	for idx, region := range regionPreference {
+		if slices.Contains(candidate.tags, region) {
+			bonus += 0.01 * float64(len(regionPreference)-idx)
+			break
+		}
+	}
+
+	for _, tag := range candidate.tags {
+		if slices.Contains(game.tags, tag) {
+			if regionTags[tag] {
+				bonus += 0.1
+			} else {
+				bonus += 0.02
+			}
+		}
+	}
+
+	for _, tag := range candidate.tags {
+		for _, devTag := range developmentTags {
+			if strings.HasPrefix(tag, devTag) && !slices.ContainsFunc(game.tags, func(t string) bool {
+				return strings.HasPrefix(t, devTag)
+			}) {
+				bonus -= 0.05
+			}
+		}
+	}
+
+	return bonus
+}
+
+// RankArtCandidates scores every thumbnail against the game name and returns the best matches above the
+// threshold, highest score first.
+func RankArtCandidates(displayName string, artList shared.Items, limit int) []ArtCandidate {
+	game := parseTitle(LibretroThumbnailName(displayName))
+
+	var candidates []ArtCandidate
+	for _, art := range artList {
+		if art.IsDirectory {
+			continue
+		}
+
+		candidate := parseTitle(art.Filename)
+
+		similarity := titleSimilarity(game.tokens, candidate.tokens)
+		if similarity < ArtMatchThreshold {
+			continue
+		}
+
+		candidates = append(candidates, ArtCandidate{
+			Filename: art.Filename,
+			Score:    similarity + tagBonus(game, candidate),
+		})
+	}
+
+	slices.SortStableFunc(candidates, compareScores)
+
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	return candidates
+}
@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"mortar/models"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"github.com/disintegration/imaging"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	ArtModeFit  = "FIT"
+	ArtModeFill = "FILL"
+)
+
+const (
+	ArtFormatPNG  = "PNG"
+	ArtFormatJPEG = "JPEG"
+)
+
+const (
+	ArtPositionCenter      = "CENTER"
+	ArtPositionBottom      = "BOTTOM"
+	ArtPositionBottomLeft  = "BOTTOM_LEFT"
+	ArtPositionBottomRight = "BOTTOM_RIGHT"
+)
+
+const defaultCompositeScale = 0.5
+
+var configuredArtProcessing *models.ArtProcessing
+
+// SetArtProcessing overrides the media layout's processing defaults with the configured values.
+func SetArtProcessing(processing *models.ArtProcessing) {
+	configuredArtProcessing = processing
+}
+
+// ActiveArtProcessing is the active media layout's processing with any configured values laid over it.
+func ActiveArtProcessing() models.ArtProcessing {
+	processing := ActiveMediaLayout().Processing
+
+	if configuredArtProcessing == nil {
+		return processing
+	}
+
+	if configuredArtProcessing.Width > 0 || configuredArtProcessing.Height > 0 {
+		processing.Width = configuredArtProcessing.Width
+		processing.Height = configuredArtProcessing.Height
+	}
+	if configuredArtProcessing.Mode != "" {
+		processing.Mode = strings.ToUpper(configuredArtProcessing.Mode)
+	}
+	if configuredArtProcessing.Format != "" {
+		processing.Format = strings.ToUpper(configuredArtProcessing.Format)
+	}
+	if configuredArtProcessing.Composite != nil {
+		processing.Composite = configuredArtProcessing.Composite
+	}
+
+	return processing
+}
+
+func ArtExtension(processing models.ArtProcessing) string {
+	switch processing.Format {
+	case ArtFormatJPEG, "JPG":
+		return ".jpg"
+	default:
+		return ".png"
+	}
+}
+
+// ProcessArt decodes downloaded art (PNG, JPEG or WebP), builds the composite if one is configured, sizes it and
+// saves it over artPath in the format picked by its extension.
+func ProcessArt(platform models.Platform, game shared.Item, artPath string) error {
+	processing := ActiveArtProcessing()
+
+	src, err := imaging.Open(artPath)
+	if err != nil {
+		common.DeleteFile(artPath)
+		return fmt.Errorf("unable to open art: %w", err)
+	}
+
+	var dst image.Image
+	if processing.Composite != nil {
+		dst, err = compositeArt(platform, game, src, processing)
+		if err != nil {
+			gaba.GetLoggerInstance().Debug("Unable to build composite, using plain art", "game", game.DisplayName, "error", err)
+			dst = sizeArt(src, processing)
+		}
+	} else {
+		dst = sizeArt(src, processing)
+	}
+
+	if err := imaging.Save(dst, artPath, imaging.JPEGQuality(90)); err != nil {
+		common.DeleteFile(artPath)
+		return fmt.Errorf("unable to save processed art: %w", err)
+	}
+
+	gaba.GetLoggerInstance().Debug("Processed art", "path", artPath, "processing", processing)
+
+	return nil
+}
+
+// sizeArt never scales art up. With only one dimension set the aspect ratio is kept.
+func sizeArt(src image.Image, processing models.ArtProcessing) image.Image {
+	bounds := src.Bounds()
+	width, height := processing.Width, processing.Height
+
+	switch {
+	case width > 0 && height > 0:
+		if processing.Mode == ArtModeFill {
+			// Art smaller than the size is cropped to its aspect ratio at the art's own size instead
+			scale := min(1, float64(bounds.Dx())/float64(width), float64(bounds.Dy())/float64(height))
+			width = max(1, int(math.Round(float64(width)*scale)))
+			height = max(1, int(math.Round(float64(height)*scale)))
+			return imaging.Fill(src, width, height, imaging.Center, imaging.Lanczos)
+		}
+		return imaging.Fit(src, width, height, imaging.Lanczos)
+	case width > 0 && bounds.Dx() > width:
+		return imaging.Resize(src, width, 0, imaging.Lanczos)
+	case height > 0 && bounds.Dy() > height:
+		return imaging.Resize(src, 0, height, imaging.Lanczos)
+	}
+
+	return src
+}
+
+// compositeArt fetches the background art type from Libretro and lays the downloaded art over it.
+func compositeArt(platform models.Platform, game shared.Item, overlay image.Image, processing models.ArtProcessing) (image.Image, error) {
+	composite := processing.Composite
+
+	artType, ok := shared.ArtDownloadTypeFromString[strings.ToUpper(composite.Background)]
+	if !ok {
+		return nil, fmt.Errorf("unknown composite background %q", composite.Background)
+	}
+
+	candidates, err := libretroArtProvider{artType: artType}.Candidates(platform, game, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, ErrNoArtFound
+	}
+
+	tempFile, err := os.CreateTemp("", "mortar-composite-*"+filepath.Ext(candidates[0].Filename))
+	if err != nil {
+		return nil, err
+	}
+	tempPath := tempFile.Name()
+	tempFile.Close()
+	defer os.Remove(tempPath)
+
+	if err := downloadFile(candidates[0].URL, tempPath, candidates[0].Headers); err != nil {
+		return nil, err
+	}
+
+	background, err := imaging.Open(tempPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open composite background: %w", err)
+	}
+
+	canvas := sizeArt(background, processing)
+
+	scale := composite.Scale
+	if scale <= 0 || scale > 1 {
+		scale = defaultCompositeScale
+	}
+
+	canvasBounds := canvas.Bounds()
+	overlay = imaging.Fit(overlay, int(float64(canvasBounds.Dx())*scale), int(float64(canvasBounds.Dy())*scale), imaging.Lanczos)
+
+	return imaging.Overlay(canvas, overlay, overlayPosition(canvasBounds, overlay.Bounds(), composite.Position), 1), nil
+}
+
+func overlayPosition(canvas, overlay image.Rectangle, position string) image.Point {
+	margin := canvas.Dx() / 40
+	bottom := canvas.Dy() - overlay.Dy() - margin
+
+	switch strings.ToUpper(position) {
+	case ArtPositionCenter:
+		return image.Pt((canvas.Dx()-overlay.Dx())/2, (canvas.Dy()-overlay.Dy())/2)
+	case ArtPositionBottom:
+		return image.Pt((canvas.Dx()-overlay.Dx())/2, bottom)
+	case ArtPositionBottomLeft:
+		return image.Pt(margin, bottom)
+	default:
+		return image.Pt(canvas.Dx()-overlay.Dx()-margin, bottom)
+	}
+}
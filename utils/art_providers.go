@@ -0,0 +1,375 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"mortar/clients"
+	"mortar/models"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"qlova.tech/sum"
+)
+
+const (
+	ArtProviderRomM          = "ROMM"
+	ArtProviderLibretro      = "LIBRETRO"
+	ArtProviderLocal         = "LOCAL"
+	ArtProviderScreenScraper = "SCREENSCRAPER"
+)
+
+const screenScraperDefaultURL = "https://api.screenscraper.fr/api2"
+
+// ArtProvider finds art for a game. Providers are tried in the order they are configured until one
+// returns a candidate.
+type ArtProvider interface {
+	Name() string
+	Candidates(platform models.Platform, game shared.Item, limit int) ([]ArtCandidate, error)
+}
+
+type artProviderFactory func(config models.ArtProviderConfig) (ArtProvider, error)
+
+var artProviderFactories = map[string]artProviderFactory{
+	ArtProviderRomM: func(config models.ArtProviderConfig) (ArtProvider, error) {
+		return romMArtProvider{large: strings.EqualFold(config.Variant, "large")}, nil
+	},
+	ArtProviderLibretro: func(config models.ArtProviderConfig) (ArtProvider, error) {
+		artType, ok := shared.ArtDownloadTypeFromString[strings.ToUpper(config.ArtType)]
+		if !ok {
+			artType = shared.ArtDownloadTypes.BOX_ART
+		}
+		return libretroArtProvider{artType: artType, label: config.ArtType}, nil
+	},
+	ArtProviderLocal: func(config models.ArtProviderConfig) (ArtProvider, error) {
+		if config.Path == "" {
+			return nil, fmt.Errorf("local art provider requires a path")
+		}
+		return localArtProvider{path: config.Path}, nil
+	},
+	ArtProviderScreenScraper: func(config models.ArtProviderConfig) (ArtProvider, error) {
+		baseURL := config.URL
+		if baseURL == "" {
+			baseURL = screenScraperDefaultURL
+		}
+		media := config.Media
+		if media == "" {
+			media = "box-2D"
+		}
+		return screenScraperArtProvider{config: config, baseURL: baseURL, media: media}, nil
+	},
+}
+
+// ArtProviderChain returns the providers for a platform. Platform configuration wins over host configuration.
+// Without either, RomM hosts use RomM covers and everything else uses Libretro with the configured art type.
+func ArtProviderChain(platform models.Platform, downloadType sum.Int[shared.ArtDownloadType]) []ArtProvider {
+	logger := gaba.GetLoggerInstance()
+
+	configs := platform.ArtProviders
+	if len(configs) == 0 {
+		configs = platform.Host.ArtProviders
+	}
+
+	if len(configs) == 0 {
+		if platform.Host.HostType == shared.HostTypes.ROMM {
+			return []ArtProvider{romMArtProvider{}}
+		}
+		return []ArtProvider{libretroArtProvider{artType: downloadType}}
+	}
+
+	var chain []ArtProvider
+	for _, config := range configs {
+		factory, ok := artProviderFactories[strings.ToUpper(config.Type)]
+		if !ok {
+			logger.Error("Unknown art provider", "type", config.Type)
+			continue
+		}
+
+		provider, err := factory(config)
+		if err != nil {
+			logger.Error("Unable to build art provider", "type", config.Type, "error", err)
+			continue
+		}
+
+		chain = append(chain, provider)
+	}
+
+	return chain
+}
+
+// ArtPickerProviders is the configured chain plus the RomM cover and the Libretro box art, title screen and snap
+// whenever the chain doesn't already include them.
+func ArtPickerProviders(platform models.Platform, downloadType sum.Int[shared.ArtDownloadType]) []ArtProvider {
+	chain := ArtProviderChain(platform, downloadType)
+
+	hasRomM := false
+	libretroTypes := make(map[sum.Int[shared.ArtDownloadType]]bool)
+	for _, provider := range chain {
+		switch p := provider.(type) {
+		case romMArtProvider:
+			hasRomM = true
+		case libretroArtProvider:
+			libretroTypes[p.artType] = true
+		}
+	}
+
+	if platform.Host.HostType == shared.HostTypes.ROMM && !hasRomM {
+		chain = append(chain, romMArtProvider{})
+	}
+
+	extras := []libretroArtProvider{
+		{artType: shared.ArtDownloadTypes.BOX_ART, label: "Box Art"},
+		{artType: shared.ArtDownloadTypes.TITLE_SCREEN, label: "Title Screen"},
+		{artType: shared.ArtDownloadTypes.SCREENSHOTS, label: "Snap"},
+	}
+	for _, extra := range extras {
+		if !libretroTypes[extra.artType] {
+			chain = append(chain, extra)
+		}
+	}
+
+	return chain
+}
+
+type romMArtProvider struct {
+	large bool
+}
+
+func (p romMArtProvider) Name() string {
+	if p.large {
+		return "RomM Large Cover"
+	}
+	return "RomM Cover"
+}
+
+func (p romMArtProvider) Candidates(platform models.Platform, game shared.Item, limit int) ([]ArtCandidate, error) {
+	if platform.Host.HostType != shared.HostTypes.ROMM || game.ArtURL == "" {
+		return nil, nil
+	}
+
+	artPath := strings.Split(game.ArtURL, "?")[0] // For the query string caching stuff
+
+	if p.large {
+		artPath = strings.Replace(artPath, "/small.", "/big.", 1)
+	}
+
+	client := clients.NewRomMClient(platform.Host.RootURI, platform.Host.Port, platform.Host.Username, platform.Host.Password)
+	artURL, err := client.BuildArtURL(artPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return []ArtCandidate{{
+		Provider: p.Name(),
+		Filename: filepath.Base(artPath),
+		URL:      artURL,
+		Headers:  client.BuildDownloadHeaders(),
+		Score:    1,
+	}}, nil
+}
+
+type libretroArtProvider struct {
+	artType sum.Int[shared.ArtDownloadType]
+	label   string
+}
+
+func (p libretroArtProvider) Name() string {
+	if p.label == "" {
+		return "Libretro"
+	}
+	return "Libretro " + p.label
+}
+
+func (p libretroArtProvider) Candidates(platform models.Platform, game shared.Item, limit int) ([]ArtCandidate, error) {
+	client := common.NewThumbnailClient(p.artType)
+
+	// The systems mapping wins so tags missing from the shared library still get art once mapped.
+	var hostSubdirectory string
+	if system, ok := LibretroSystemName(platform.SystemTag); ok {
+		hostSubdirectory = LibretroThumbnailDirectory(system, p.artType)
+	} else {
+		hostSubdirectory = client.BuildThumbnailSection(platform.SystemTag).HostSubdirectory
+	}
+
+	artList, err := ListThumbnailsCached(hostSubdirectory, func() (shared.Items, error) {
+		return client.ListDirectory(hostSubdirectory)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch artlist: %w", err)
+	}
+
+	candidates := RankArtCandidates(game.DisplayName, artList, limit)
+	for i := range candidates {
+		artURL, err := url.JoinPath(LibretroThumbnailRoot, hostSubdirectory, candidates[i].Filename)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates[i].Provider = p.Name()
+		candidates[i].HostSubdirectory = hostSubdirectory
+		candidates[i].URL = artURL
+	}
+
+	return candidates, nil
+}
+
+// localArtProvider matches art in a folder on the SD card, preferring a sub folder named after the system tag.
+type localArtProvider struct {
+	path string
+}
+
+func (p localArtProvider) Name() string {
+	return "Local Art"
+}
+
+func (p localArtProvider) Candidates(platform models.Platform, game shared.Item, limit int) ([]ArtCandidate, error) {
+	directory := p.path
+	if platform.SystemTag != "" {
+		if info, err := os.Stat(filepath.Join(p.path, platform.SystemTag)); err == nil && info.IsDir() {
+			directory = filepath.Join(p.path, platform.SystemTag)
+		}
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var images shared.Items
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".png", ".jpg", ".jpeg", ".webp":
+			images = append(images, shared.Item{Filename: entry.Name()})
+		}
+	}
+
+	candidates := RankArtCandidates(game.DisplayName, images, limit)
+	for i := range candidates {
+		candidates[i].Provider = p.Name()
+		candidates[i].SourcePath = filepath.Join(directory, candidates[i].Filename)
+	}
+
+	return candidates, nil
+}
+
+// screenScraperArtProvider talks to the ScreenScraper API or anything that speaks the same jeuInfos protocol.
+type screenScraperArtProvider struct {
+	config  models.ArtProviderConfig
+	baseURL string
+	media   string
+}
+
+type screenScraperResponse struct {
+	Response struct {
+		Jeu struct {
+			Medias []struct {
+				Type   string `json:"type"`
+				Region string `json:"region"`
+				URL    string `json:"url"`
+				Format string `json:"format"`
+			} `json:"medias"`
+		} `json:"jeu"`
+	} `json:"response"`
+}
+
+var screenScraperRegionPreference = []string{"us", "wor", "eu", "ss", "jp"}
+
+func (p screenScraperArtProvider) Name() string {
+	return "ScreenScraper"
+}
+
+func (p screenScraperArtProvider) Candidates(platform models.Platform, game shared.Item, limit int) ([]ArtCandidate, error) {
+	u, err := url.Parse(p.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse ScreenScraper URL: %w", err)
+	}
+
+	u = u.JoinPath("jeuInfos.php")
+
+	params := url.Values{}
+	params.Add("output", "json")
+	params.Add("softname", "Mortar")
+	params.Add("romtype", "rom")
+	params.Add("romnom", game.Filename)
+	if game.FileSize != "" {
+		params.Add("romtaille", game.FileSize)
+	}
+	if p.config.SystemID != "" {
+		params.Add("systemeid", p.config.SystemID)
+	}
+	if p.config.Username != "" {
+		params.Add("ssid", p.config.Username)
+		params.Add("sspassword", p.config.Password)
+	}
+	u.RawQuery = params.Encode()
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	resp, err := httpClient.Get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to call ScreenScraper: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// ScreenScraper answers a ROM it doesn't know with a 404.
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ScreenScraper returned %s", resp.Status)
+	}
+
+	var ssResponse screenScraperResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ssResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode ScreenScraper JSON: %w", err)
+	}
+
+	var candidates []ArtCandidate
+	for _, media := range ssResponse.Response.Jeu.Medias {
+		if media.Type != p.media || media.URL == "" {
+			continue
+		}
+
+		score := 1.0
+		if idx := slices.Index(screenScraperRegionPreference, media.Region); idx != -1 {
+			score += 0.01 * float64(len(screenScraperRegionPreference)-idx)
+		}
+
+		format := media.Format
+		if format == "" {
+			format = "png"
+		}
+
+		candidates = append(candidates, ArtCandidate{
+			Provider: p.Name(),
+			Filename: game.Filename + "." + format,
+			URL:      media.URL,
+			Score:    score,
+		})
+	}
+
+	slices.SortStableFunc(candidates, compareScores)
+
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	return candidates, nil
+}
+
+func compareScores(a, b ArtCandidate) int {
+	switch {
+	case a.Score > b.Score:
+		return -1
+	case a.Score < b.Score:
+		return 1
+	}
+	return 0
+}
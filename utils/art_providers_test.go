@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"mortar/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+)
+
+func newScreenScraperStandIn(t *testing.T, handler http.HandlerFunc) ArtProvider {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	provider, err := artProviderFactories[ArtProviderScreenScraper](models.ArtProviderConfig{
+		Type:     ArtProviderScreenScraper,
+		URL:      server.URL,
+		Username: "user",
+		Password: "secret",
+		SystemID: "1",
+	})
+	if err != nil {
+		t.Fatalf("unable to build provider: %v", err)
+	}
+
+	return provider
+}
+
+func TestScreenScraperArtProviderMatch(t *testing.T) {
+	provider := newScreenScraperStandIn(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jeuInfos.php" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+
+		query := r.URL.Query()
+		for key, want := range map[string]string{
+			"romnom": "Sonic the Hedgehog (USA, Europe).md", "systemeid": "1", "ssid": "user", "sspassword": "secret",
+		} {
+			if got := query.Get(key); got != want {
+				t.Errorf("%s = %q, want %q", key, got, want)
+			}
+		}
+
+		_, _ = w.Write([]byte(`{"response": {"jeu": {"medias": [
+			{"type": "ss", "region": "us", "url": "https://example.com/snap.png", "format": "png"},
+			{"type": "box-2D", "region": "jp", "url": "https://example.com/box-jp.png", "format": "png"},
+			{"type": "box-2D", "region": "us", "url": "https://example.com/box-us.jpg", "format": "jpg"},
+			{"type": "box-2D", "region": "eu", "url": ""}
+		]}}}`))
+	})
+
+	game := shared.Item{Filename: "Sonic the Hedgehog (USA, Europe).md"}
+
+	candidates, err := provider.Candidates(models.Platform{}, game, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(candidates) != 2 {
+		t.Fatalf("got %d candidates, want 2: %+v", len(candidates), candidates)
+	}
+
+	if candidates[0].URL != "https://example.com/box-us.jpg" || candidates[1].URL != "https://example.com/box-jp.png" {
+		t.Errorf("candidates not ordered by region preference: %+v", candidates)
+	}
+
+	if candidates[0].Filename != game.Filename+".jpg" {
+		t.Errorf("filename = %q, want %q", candidates[0].Filename, game.Filename+".jpg")
+	}
+
+	if candidates[0].Headers != nil {
+		t.Errorf("ScreenScraper media should not need headers, got %v", candidates[0].Headers)
+	}
+
+	limited, err := provider.Candidates(models.Platform{}, game, 1)
+	if err != nil || len(limited) != 1 {
+		t.Errorf("limit not applied, got %d candidates and error %v", len(limited), err)
+	}
+}
+
+func TestScreenScraperArtProviderNoMatch(t *testing.T) {
+	tests := map[string]http.HandlerFunc{
+		"unknown rom": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Erreur : Rom/Iso/Dossier non trouvée !", http.StatusNotFound)
+		},
+		"no media of the configured type": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"response": {"jeu": {"medias": [{"type": "ss", "region": "us", "url": "https://example.com/snap.png"}]}}}`))
+		},
+	}
+
+	for name, handler := range tests {
+		t.Run(name, func(t *testing.T) {
+			provider := newScreenScraperStandIn(t, handler)
+
+			candidates, err := provider.Candidates(models.Platform{}, shared.Item{Filename: "Missing.md"}, 5)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(candidates) != 0 {
+				t.Errorf("got %d candidates, want none", len(candidates))
+			}
+		})
+	}
+}
+
+func TestScreenScraperArtProviderError(t *testing.T) {
+	tests := map[string]http.HandlerFunc{
+		"server error": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "API fermée", http.StatusInternalServerError)
+		},
+		"bad credentials": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Erreur de login", http.StatusUnauthorized)
+		},
+		"malformed response": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"response": `))
+		},
+	}
+
+	for name, handler := range tests {
+		t.Run(name, func(t *testing.T) {
+			provider := newScreenScraperStandIn(t, handler)
+
+			if _, err := provider.Candidates(models.Platform{}, shared.Item{Filename: "Game.md"}, 5); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"mortar/models"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+)
+
+const DefaultCatalogCacheTTL = 24 * time.Hour
+
+const catalogCacheDirectory = "catalogs"
+
+// CatalogCacheEntry is a platform's game list as it was last fetched from the host. ETag and LastModified are the
+// validators the host sent, used to ask whether the list changed once the TTL runs out.
+type CatalogCacheEntry struct {
+	Host         string       `json:"host"`
+	Platform     string       `json:"platform"`
+	FetchedAt    time.Time    `json:"fetched_at"`
+	ETag         string       `json:"etag,omitempty"`
+	LastModified string       `json:"last_modified,omitempty"`
+	Items        shared.Items `json:"items"`
+
+	Metadata  models.GameMetadataIndex `json:"metadata,omitempty"`
+	CharIndex map[string]int           `json:"char_index,omitempty"`
+}
+
+var catalogCacheTTL = DefaultCatalogCacheTTL
+
+// catalogFetchTimes remembers when each loaded list was fetched so the game list can label it without rereading it.
+var catalogFetchTimes sync.Map
+
+// catalogDetails holds the metadata and letter index of each loaded list.
+var catalogDetails sync.Map
+
+type catalogDetail struct {
+	metadata  models.GameMetadataIndex
+	charIndex map[string]int
+}
+
+// SetCatalogCacheTTL takes a Go duration such as 12h, or a number of days such as 3d. Empty uses the default and 0
+// checks the host every time a list is opened.
+func SetCatalogCacheTTL(raw string) {
+	catalogCacheTTL = DefaultCatalogCacheTTL
+
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return
+	}
+
+	ttl, err := parseCatalogCacheTTL(raw)
+	if err != nil {
+		gaba.GetLoggerInstance().Error("Invalid catalog_cache_ttl, using the default", "value", raw, "error", err)
+		return
+	}
+
+	catalogCacheTTL = ttl
+}
+
+func CatalogCacheTTL() time.Duration {
+	return catalogCacheTTL
+}
+
+func parseCatalogCacheTTL(raw string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		count, err := strconv.ParseFloat(days, 64)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(count * float64(24*time.Hour)), nil
+	}
+
+	if raw == "0" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(raw)
+}
+
+func (e *CatalogCacheEntry) Age() time.Duration {
+	return time.Since(e.FetchedAt)
+}
+
+func (e *CatalogCacheEntry) IsExpired() bool {
+	return e.Age() >= catalogCacheTTL
+}
+
+// LoadCatalogCache returns the cached list for a platform, or nil when there is none. Megathread lists cached by
+// older versions are picked up as well.
+func LoadCatalogCache(platform models.Platform) *CatalogCacheEntry {
+	logger := gaba.GetLoggerInstance()
+
+	data, err := os.ReadFile(catalogCachePath(platform))
+	if err == nil {
+		var entry CatalogCacheEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			logger.Debug("Unable to unmarshal cached catalog", "platform", platform.Name, "error", err)
+			return nil
+		}
+
+		rememberCatalog(catalogCachePath(platform), &entry)
+		return &entry
+	}
+
+	return loadLegacyMegathreadCache(platform)
+}
+
+func SaveCatalogCache(platform models.Platform, entry *CatalogCacheEntry) error {
+	entry.Host = platform.Host.DisplayName
+	entry.Platform = platform.Name
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("unable to marshal catalog: %w", err)
+	}
+
+	cachePath := catalogCachePath(platform)
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return fmt.Errorf("unable to make catalog cache directory: %w", err)
+	}
+
+	if err := os.WriteFile(cachePath, data, 0644); err != nil {
+		return fmt.Errorf("unable to write catalog: %w", err)
+	}
+
+	rememberCatalog(cachePath, entry)
+	_ = os.Remove(legacyMegathreadCachePath(platform))
+
+	return nil
+}
+
+func HasCatalogCache(platform models.Platform) bool {
+	if _, err := os.Stat(catalogCachePath(platform)); err == nil {
+		return true
+	}
+
+	_, err := os.Stat(legacyMegathreadCachePath(platform))
+	return err == nil && platform.Host.HostType == shared.HostTypes.MEGATHREAD
+}
+
+// CatalogFetchedAt is when the list loaded for the platform this session was fetched.
+func CatalogFetchedAt(platform models.Platform) (time.Time, bool) {
+	fetchedAt, ok := catalogFetchTimes.Load(catalogCachePath(platform))
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return fetchedAt.(time.Time), true
+}
+
+// CatalogMetadata is the metadata that came with the list loaded for the platform this session, nil when the host
+// has none.
+func CatalogMetadata(platform models.Platform) models.GameMetadataIndex {
+	detail, ok := catalogDetails.Load(catalogCachePath(platform))
+	if !ok {
+		return nil
+	}
+
+	return detail.(catalogDetail).metadata
+}
+
+// CatalogCharIndex is where each starting letter begins in the host's own ordering of the list loaded for the
+// platform, nil when the host doesn't report it.
+func CatalogCharIndex(platform models.Platform) map[string]int {
+	detail, ok := catalogDetails.Load(catalogCachePath(platform))
+	if !ok {
+		return nil
+	}
+
+	return detail.(catalogDetail).charIndex
+}
+
+func rememberCatalog(cachePath string, entry *CatalogCacheEntry) {
+	catalogFetchTimes.Store(cachePath, entry.FetchedAt)
+
+	if len(entry.Metadata) > 0 || len(entry.CharIndex) > 0 {
+		catalogDetails.Store(cachePath, catalogDetail{metadata: entry.Metadata, charIndex: entry.CharIndex})
+	} else {
+		catalogDetails.Delete(cachePath)
+	}
+}
+
+// CatalogCacheSize is the disk space used by a host's cached lists.
+func CatalogCacheSize(host models.Host) int64 {
+	var size int64
+
+	_ = filepath.WalkDir(catalogHostDirectory(host), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size
+}
+
+func DeleteCatalogCache(host models.Host) error {
+	directory := catalogHostDirectory(host)
+
+	catalogFetchTimes.Range(func(key, _ any) bool {
+		if strings.HasPrefix(key.(string), directory+string(filepath.Separator)) {
+			catalogFetchTimes.Delete(key)
+			catalogDetails.Delete(key)
+		}
+		return true
+	})
+
+	for _, platform := range host.Platforms {
+		platform.Host = host
+		_ = os.Remove(legacyMegathreadCachePath(platform))
+	}
+
+	return os.RemoveAll(directory)
+}
+
+// FormatCacheAge turns an age into text like "3 days ago".
+func FormatCacheAge(age time.Duration) string {
+	plural := func(count int, unit string) string {
+		if count == 1 {
+			return fmt.Sprintf("1 %s ago", unit)
+		}
+		return fmt.Sprintf("%d %ss ago", count, unit)
+	}
+
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return plural(int(age.Minutes()), "minute")
+	case age < 24*time.Hour:
+		return plural(int(age.Hours()), "hour")
+	default:
+		return plural(int(age.Hours()/24), "day")
+	}
+}
+
+// FormatSize turns a byte count into text like 1.4 MB.
+func FormatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGT"[exp])
+}
+
+func catalogHostDirectory(host models.Host) string {
+	return filepath.Join(CacheDirectory(), catalogCacheDirectory, cacheSafeName(host.DisplayName))
+}
+
+func catalogCachePath(platform models.Platform) string {
+	return filepath.Join(catalogHostDirectory(platform.Host), cacheSafeName(platform.Name)+".json")
+}
+
+func cacheSafeName(name string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(strings.TrimSpace(name))
+	if name == "" {
+		return "_"
+	}
+	return name
+}
+
+func legacyMegathreadCachePath(platform models.Platform) string {
+	return filepath.Join(CacheDirectory(), CachedMegaThreadJsonFilename(platform.Host.DisplayName, platform.Name))
+}
+
+func loadLegacyMegathreadCache(platform models.Platform) *CatalogCacheEntry {
+	if platform.Host.HostType != shared.HostTypes.MEGATHREAD {
+		return nil
+	}
+
+	cachePath := legacyMegathreadCachePath(platform)
+
+	info, err := os.Stat(cachePath)
+	if err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		return nil
+	}
+
+	var items shared.Items
+	if err := json.Unmarshal(data, &items); err != nil {
+		gaba.GetLoggerInstance().Debug("Unable to unmarshal cached Megathread JSON data", "error", err)
+		return nil
+	}
+
+	catalogFetchTimes.Store(catalogCachePath(platform), info.ModTime())
+
+	return &CatalogCacheEntry{
+		Host:      platform.Host.DisplayName,
+		Platform:  platform.Name,
+		FetchedAt: info.ModTime(),
+		Items:     items,
+	}
+}
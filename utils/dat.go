@@ -0,0 +1,301 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"mortar/models"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const DatsDirectory = "dats"
+
+type logiqxDatafile struct {
+	Header struct {
+		Name        string `xml:"name"`
+		Description string `xml:"description"`
+	} `xml:"header"`
+	Games    []logiqxGame `xml:"game"`
+	Machines []logiqxGame `xml:"machine"`
+}
+
+type logiqxGame struct {
+	Name         string      `xml:"name,attr"`
+	CloneOf      string      `xml:"cloneof,attr"`
+	RomOf        string      `xml:"romof,attr"`
+	IsBios       string      `xml:"isbios,attr"`
+	IsDevice     string      `xml:"isdevice,attr"`
+	IsMechanical string      `xml:"ismechanical,attr"`
+	Runnable     string      `xml:"runnable,attr"`
+	Description  string      `xml:"description"`
+	Year         string      `xml:"year"`
+	Manufacturer string      `xml:"manufacturer"`
+	Roms         []logiqxRom `xml:"rom"`
+	DeviceRefs   []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"device_ref"`
+}
+
+type logiqxRom struct {
+	Name   string `xml:"name,attr"`
+	Size   int64  `xml:"size,attr"`
+	CRC    string `xml:"crc,attr"`
+	MD5    string `xml:"md5,attr"`
+	SHA1   string `xml:"sha1,attr"`
+	Status string `xml:"status,attr"`
+	Merge  string `xml:"merge,attr"`
+}
+
+// ResolveDatPath returns the path of a DAT file. Relative names are looked up in the DAT directory.
+func ResolveDatPath(datFile string) string {
+	if datFile == "" || filepath.IsAbs(datFile) {
+		return datFile
+	}
+
+	if _, err := os.Stat(datFile); err == nil {
+		return datFile
+	}
+
+	return filepath.Join(DatsDirectory, datFile)
+}
+
+func ListDatFiles() ([]string, error) {
+	entries, err := os.ReadDir(DatsDirectory)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	var datFiles []string
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		datFiles = append(datFiles, entry.Name())
+	}
+
+	return datFiles, nil
+}
+
+func LoadDat(datFile string) (*models.Dat, error) {
+	data, err := os.ReadFile(ResolveDatPath(datFile))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read DAT file: %w", err)
+	}
+
+	return ParseDat(data)
+}
+
+// ParseDat accepts either Logiqx XML or ClrMamePro text DATs.
+func ParseDat(data []byte) (*models.Dat, error) {
+	trimmed := bytes.TrimLeftFunc(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), unicode.IsSpace)
+
+	if bytes.HasPrefix(trimmed, []byte("<")) {
+		return parseLogiqxDat(trimmed)
+	}
+
+	return parseClrMameProDat(trimmed)
+}
+
+func parseLogiqxDat(data []byte) (*models.Dat, error) {
+	var datafile logiqxDatafile
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+
+	if err := decoder.Decode(&datafile); err != nil {
+		return nil, fmt.Errorf("unable to parse Logiqx DAT: %w", err)
+	}
+
+	dat := &models.Dat{
+		Name:        datafile.Header.Name,
+		Description: datafile.Header.Description,
+	}
+
+	for _, game := range append(datafile.Games, datafile.Machines...) {
+		datGame := models.DatGame{
+			Name:         game.Name,
+			Description:  game.Description,
+			Year:         game.Year,
+			Manufacturer: game.Manufacturer,
+			CloneOf:      game.CloneOf,
+			RomOf:        game.RomOf,
+			IsBios:       game.IsBios == "yes",
+			IsDevice:     game.IsDevice == "yes" || game.Runnable == "no",
+			IsMechanical: game.IsMechanical == "yes",
+		}
+
+		for _, ref := range game.DeviceRefs {
+			datGame.DeviceRefs = append(datGame.DeviceRefs, ref.Name)
+		}
+
+		for _, rom := range game.Roms {
+			datGame.Roms = append(datGame.Roms, models.DatRom{
+				Name:   rom.Name,
+				Size:   rom.Size,
+				CRC:    strings.ToLower(rom.CRC),
+				MD5:    strings.ToLower(rom.MD5),
+				SHA1:   strings.ToLower(rom.SHA1),
+				Status: rom.Status,
+				Merge:  rom.Merge,
+			})
+		}
+
+		dat.Games = append(dat.Games, datGame)
+	}
+
+	if len(dat.Games) == 0 {
+		return nil, errors.New("no games found in Logiqx DAT")
+	}
+
+	return dat, nil
+}
+
+func parseClrMameProDat(data []byte) (*models.Dat, error) {
+	tokens, err := tokenizeClrMamePro(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	dat := &models.Dat{}
+
+	pos := 0
+	for pos < len(tokens) {
+		blockName := tokens[pos]
+		if pos+1 >= len(tokens) || tokens[pos+1] != "(" {
+			return nil, fmt.Errorf("unexpected token %q in ClrMamePro DAT", blockName)
+		}
+
+		block, next, err := readClrMameProBlock(tokens, pos+2)
+		if err != nil {
+			return nil, err
+		}
+		pos = next
+
+		switch blockName {
+		case "clrmamepro":
+			dat.Name = block.values["name"]
+			dat.Description = block.values["description"]
+		case "game", "machine", "resource":
+			game := models.DatGame{
+				Name:         block.values["name"],
+				Description:  block.values["description"],
+				Year:         block.values["year"],
+				Manufacturer: block.values["manufacturer"],
+				CloneOf:      block.values["cloneof"],
+				RomOf:        block.values["romof"],
+				IsBios:       blockName == "resource",
+			}
+
+			for _, rom := range block.children["rom"] {
+				size, _ := strconv.ParseInt(rom["size"], 10, 64)
+				game.Roms = append(game.Roms, models.DatRom{
+					Name:   rom["name"],
+					Size:   size,
+					CRC:    strings.ToLower(rom["crc"]),
+					MD5:    strings.ToLower(rom["md5"]),
+					SHA1:   strings.ToLower(rom["sha1"]),
+					Status: rom["flags"],
+					Merge:  rom["merge"],
+				})
+			}
+
+			dat.Games = append(dat.Games, game)
+		}
+	}
+
+	if len(dat.Games) == 0 {
+		return nil, errors.New("no games found in ClrMamePro DAT")
+	}
+
+	return dat, nil
+}
+
+type clrMameProBlock struct {
+	values   map[string]string
+	children map[string][]map[string]string
+}
+
+func readClrMameProBlock(tokens []string, pos int) (clrMameProBlock, int, error) {
+	block := clrMameProBlock{
+		values:   make(map[string]string),
+		children: make(map[string][]map[string]string),
+	}
+
+	for pos < len(tokens) {
+		key := tokens[pos]
+		if key == ")" {
+			return block, pos + 1, nil
+		}
+
+		if pos+1 >= len(tokens) {
+			break
+		}
+
+		if tokens[pos+1] == "(" {
+			child, next, err := readClrMameProBlock(tokens, pos+2)
+			if err != nil {
+				return block, next, err
+			}
+			block.children[key] = append(block.children[key], child.values)
+			pos = next
+			continue
+		}
+
+		if _, exists := block.values[key]; !exists {
+			block.values[key] = tokens[pos+1]
+		}
+		pos += 2
+	}
+
+	return block, pos, errors.New("unterminated block in ClrMamePro DAT")
+}
+
+func tokenizeClrMamePro(data string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	inQuotes := false
+	for _, r := range data {
+		switch {
+		case inQuotes && r == '"':
+			tokens = append(tokens, current.String())
+			current.Reset()
+			inQuotes = false
+		case inQuotes:
+			current.WriteRune(r)
+		case r == '"':
+			flush()
+			inQuotes = true
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if inQuotes {
+		return nil, errors.New("unterminated string in ClrMamePro DAT")
+	}
+
+	flush()
+
+	return tokens, nil
+}
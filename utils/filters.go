@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"mortar/models"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+)
+
+// FilterPreset is a preset available on a platform, from its host or its own filters, with whether it is on.
+type FilterPreset struct {
+	models.FilterPreset
+	Active bool
+}
+
+var filterPatterns sync.Map
+
+// PlatformFilterPresets lists the host's presets followed by the platform's. A preset is on when its name was switched
+// on from the games list, otherwise when it is enabled in the config. A platform preset replaces a host preset with the
+// same name.
+func PlatformFilterPresets(platform models.Platform) []FilterPreset {
+	var presets []FilterPreset
+
+	for _, preset := range append(slices.Clone(platform.Host.Filters.Presets), platform.Filters.Presets...) {
+		active := preset.Enabled
+		if toggled, ok := platform.FilterPresets[preset.Name]; ok {
+			active = toggled
+		}
+
+		entry := FilterPreset{FilterPreset: preset, Active: active}
+
+		if idx := slices.IndexFunc(presets, func(p FilterPreset) bool { return p.Name == preset.Name }); idx != -1 {
+			presets[idx] = entry
+			continue
+		}
+
+		presets = append(presets, entry)
+	}
+
+	return presets
+}
+
+// PlatformFilterLayers works out the rules a platform's games have to pass, as layers that each have to be passed:
+//
+//   - host exclusions always apply unless the platform has skip_exclusive_filters
+//   - host inclusions apply unless the platform has skip_inclusive_filters, is an arcade platform or has inclusions
+//     of its own, which replace them
+//   - the platform's own rules
+//   - every preset that is on, each on its own
+func PlatformFilterLayers(platform models.Platform) []models.FilterRules {
+	host := platform.Host.Filters.FilterRules
+
+	if platform.SkipExclusiveFilters {
+		host = host.WithoutExclusions()
+	}
+
+	if platform.SkipInclusiveFilters || platform.IsArcade || platform.Filters.HasInclusions() {
+		host = host.WithoutInclusions()
+	}
+
+	layers := []models.FilterRules{host, platform.Filters.FilterRules}
+
+	for _, preset := range PlatformFilterPresets(platform) {
+		if preset.Active {
+			layers = append(layers, preset.FilterRules)
+		}
+	}
+
+	return slices.DeleteFunc(layers, func(rules models.FilterRules) bool {
+		return !rules.HasInclusions() && !rules.HasExclusions()
+	})
+}
+
+// ApplyFilters keeps the items that pass every layer. Within a layer an item is dropped when any exclusive rule
+// matches, and each kind of inclusive rule that is set has to have at least one match.
+func ApplyFilters(items shared.Items, layers []models.FilterRules) shared.Items {
+	if len(layers) == 0 {
+		return items
+	}
+
+	filtered := make(shared.Items, 0, len(items))
+	for _, item := range items {
+		name := item.DisplayName
+		if name == "" {
+			name = item.Filename
+		}
+		tags := parseTitle(name).tags
+
+		if !slices.ContainsFunc(layers, func(rules models.FilterRules) bool { return !passesFilterRules(rules, name, tags) }) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
+
+func passesFilterRules(rules models.FilterRules, name string, tags []string) bool {
+	lowerName := strings.ToLower(name)
+
+	containsAny := func(filters []string) bool {
+		return slices.ContainsFunc(filters, func(filter string) bool {
+			return strings.Contains(lowerName, strings.ToLower(filter))
+		})
+	}
+
+	matchesAny := func(patterns []string) bool {
+		return slices.ContainsFunc(patterns, func(pattern string) bool {
+			re := compileFilterPattern(pattern, false)
+			return re != nil && re.MatchString(name)
+		})
+	}
+
+	hasTag := func(patterns []string) bool {
+		return slices.ContainsFunc(patterns, func(pattern string) bool {
+			re := compileFilterPattern(pattern, true)
+			return re != nil && slices.ContainsFunc(tags, re.MatchString)
+		})
+	}
+
+	if containsAny(rules.ExclusiveFilters) || matchesAny(rules.ExclusivePatterns) || hasTag(rules.ExcludeTags) {
+		return false
+	}
+
+	if len(rules.InclusiveFilters) > 0 && !containsAny(rules.InclusiveFilters) {
+		return false
+	}
+
+	if len(rules.InclusivePatterns) > 0 && !matchesAny(rules.InclusivePatterns) {
+		return false
+	}
+
+	if len(rules.IncludeTags) > 0 && !hasTag(rules.IncludeTags) {
+		return false
+	}
+
+	return true
+}
+
+// compileFilterPattern compiles a rule once. Tag rules may be written with their brackets, "(Beta)" or "[!]", and
+// match a whole tag or its first word, so "Beta" also matches "Beta 2". Invalid patterns are logged and never match.
+func compileFilterPattern(pattern string, tag bool) *regexp.Regexp {
+	key := pattern
+	if tag {
+		key = "tag:" + pattern
+	}
+
+	if re, ok := filterPatterns.Load(key); ok {
+		return re.(*regexp.Regexp)
+	}
+
+	expression := "(?i)" + pattern
+	if tag {
+		expression = "(?i)^(?:" + stripTagBrackets(strings.TrimSpace(pattern)) + ")(?: .*)?$"
+	}
+
+	re, err := regexp.Compile(expression)
+	if err != nil {
+		gaba.GetLoggerInstance().Error("Invalid filter pattern", "pattern", pattern, "error", err)
+	}
+
+	filterPatterns.Store(key, re)
+
+	return re
+}
+
+func stripTagBrackets(pattern string) string {
+	if len(pattern) < 3 {
+		return pattern
+	}
+
+	first, last, inner := pattern[0], pattern[len(pattern)-1], pattern[1:len(pattern)-1]
+	if (first == '(' && last == ')' || first == '[' && last == ']') && !strings.ContainsAny(inner, "()[]") {
+		return inner
+	}
+
+	return pattern
+}
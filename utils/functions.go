@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"image/color"
 	"io"
-	"mortar/clients"
 	"mortar/models"
 	"net"
 	"os"
@@ -20,11 +19,9 @@ import (
 	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
 	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
 	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
-	"github.com/disintegration/imaging"
 	"github.com/skip2/go-qrcode"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
-	"qlova.tech/sum"
 )
 
 const arcadeMappingFile = "resources/arcade_mapping.txt"
@@ -44,7 +41,7 @@ func GetRomDirectory() string {
 		return os.Getenv("ROM_DIRECTORY")
 	}
 
-	return common.RomDirectory
+	return ActiveMediaLayout().RomDirectory
 }
 
 func LoadConfig() (*models.Config, error) {
@@ -126,6 +123,9 @@ func SaveConfig(config *models.Config) error {
 	viper.Set("unzip_downloads", config.UnzipDownloads)
 	viper.Set("group_bin_cue", config.GroupBinCue)
 	viper.Set("group_multi_disc", config.GroupMultiDisc)
+	viper.Set("media_layout", config.MediaLayout)
+	viper.Set("art_processing", config.ArtProcessing)
+	viper.Set("catalog_cache_ttl", config.CatalogCacheTTL)
 	viper.Set("log_level", config.LogLevel)
 
 	gaba.SetRawLogLevel(config.LogLevel)
@@ -156,7 +156,7 @@ func UnzipGame(platform models.Platform, game shared.Item) ([]string, error) {
 	romDirectory := platform.LocalDirectory
 
 	if IsDev() {
-		romDirectory = strings.ReplaceAll(platform.LocalDirectory, common.RomDirectory, GetRomDirectory())
+		romDirectory = strings.ReplaceAll(platform.LocalDirectory, ActiveMediaLayout().RomDirectory, GetRomDirectory())
 		zipPath = filepath.Join(romDirectory, game.Filename)
 	}
 
@@ -275,7 +275,7 @@ func ListZipContents(platform models.Platform, game shared.Item) ([]string, erro
 	romDirectory := platform.LocalDirectory
 
 	if IsDev() {
-		romDirectory = strings.ReplaceAll(platform.LocalDirectory, common.RomDirectory, GetRomDirectory())
+		romDirectory = strings.ReplaceAll(platform.LocalDirectory, ActiveMediaLayout().RomDirectory, GetRomDirectory())
 		zipPath = filepath.Join(romDirectory, game.Filename)
 	}
 
@@ -327,9 +327,12 @@ func IsMultiDisc(platform models.Platform, game shared.Item) bool {
 	return strings.Contains(game.Filename, "(Disc") || strings.Contains(game.Filename, "(Disk")
 }
 
-func GroupBinCue(platform models.Platform, game shared.Item) {
+// GroupBinCue returns the folders the BIN/CUE files were grouped into.
+func GroupBinCue(platform models.Platform, game shared.Item) []string {
 	logger := gaba.GetLoggerInstance()
 
+	var groupedDirectories []string
+
 	unzipped, err := UnzipGame(platform, game)
 
 	if err == nil && len(unzipped) > 0 {
@@ -383,14 +386,19 @@ func GroupBinCue(platform models.Platform, game shared.Item) {
 				logger.Debug("Successfully grouped BIN/CUE files",
 					"cueFile", baseName,
 					"directory", dirPath)
+
+				groupedDirectories = append(groupedDirectories, dirPath)
 			}
 
 			return nil, nil
 		})
 	}
+
+	return groupedDirectories
 }
 
-func GroupMultiDisk(platform models.Platform, game shared.Item) error {
+// GroupMultiDisk returns the game folder holding the discs and the M3U.
+func GroupMultiDisk(platform models.Platform, game shared.Item) (string, error) {
 	logger := gaba.GetLoggerInstance()
 
 	gameFolderName := game.DisplayName
@@ -414,7 +422,7 @@ func GroupMultiDisk(platform models.Platform, game shared.Item) error {
 	gameFolderPath := filepath.Join(platform.LocalDirectory, gameFolderName)
 
 	if IsDev() {
-		romDirectory := strings.ReplaceAll(platform.LocalDirectory, common.RomDirectory, GetRomDirectory())
+		romDirectory := strings.ReplaceAll(platform.LocalDirectory, ActiveMediaLayout().RomDirectory, GetRomDirectory())
 		gameFolderPath = filepath.Join(romDirectory, gameFolderName)
 	}
 
@@ -422,7 +430,7 @@ func GroupMultiDisk(platform models.Platform, game shared.Item) error {
 		err := os.MkdirAll(gameFolderPath, 0755)
 		if err != nil {
 			logger.Error("Failed to create game directory", "error", err)
-			return err
+			return "", err
 		}
 		logger.Debug("Created new game directory", "path", gameFolderPath)
 	} else {
@@ -436,13 +444,13 @@ func GroupMultiDisk(platform models.Platform, game shared.Item) error {
 		extractedFiles, err = UnzipGame(platform, game)
 		if err != nil {
 			logger.Error("Failed to unzip game", "error", err)
-			return err
+			return "", err
 		}
 	} else {
 		romDirectory := platform.LocalDirectory
 
 		if IsDev() {
-			romDirectory = strings.ReplaceAll(platform.LocalDirectory, common.RomDirectory, GetRomDirectory())
+			romDirectory = strings.ReplaceAll(platform.LocalDirectory, ActiveMediaLayout().RomDirectory, GetRomDirectory())
 		}
 
 		extractedFiles = append(extractedFiles, filepath.Join(romDirectory, game.Filename))
@@ -511,98 +519,7 @@ func GroupMultiDisk(platform models.Platform, game shared.Item) error {
 		return nil, nil
 	})
 
-	return err
-}
-
-func FindArt(platform models.Platform, game shared.Item, downloadType sum.Int[shared.ArtDownloadType]) string {
-	logger := gaba.GetLoggerInstance()
-
-	artDirectory := ""
-
-	if IsDev() {
-		romDirectory := strings.ReplaceAll(platform.LocalDirectory, common.RomDirectory, GetRomDirectory())
-		artDirectory = filepath.Join(romDirectory, ".media")
-	} else {
-		artDirectory = filepath.Join(platform.LocalDirectory, ".media")
-	}
-
-	host := platform.Host
-
-	if host.HostType == shared.HostTypes.ROMM {
-		// Skip all this silliness and grab the art from RoMM
-		client, err := clients.BuildClient(host)
-		if err != nil {
-			return ""
-		}
-
-		rommClient := client.(*clients.RomMClient)
-
-		if game.ArtURL == "" {
-			return ""
-		}
-
-		slashIdx := strings.LastIndex(game.ArtURL, "/")
-		artSubdirectory, artFilename := game.ArtURL[:slashIdx], game.ArtURL[slashIdx+1:]
-
-		artFilename = strings.Split(artFilename, "?")[0] // For the query string caching stuff
-
-		LastSavedArtPath, err := rommClient.DownloadArt(artSubdirectory,
-			artDirectory, artFilename, game.Filename)
-
-		if err != nil {
-			return ""
-		}
-
-		return LastSavedArtPath
-	}
-
-	client := common.NewThumbnailClient(downloadType)
-	section := client.BuildThumbnailSection(platform.SystemTag)
-
-	artList, err := client.ListDirectory(section.HostSubdirectory)
-
-	if err != nil {
-		logger.Debug("Unable to fetch artlist", "error", err)
-		return ""
-	}
-
-	// toastd's trick for Libretro Thumbnail Naming
-	cleanedName := strings.ReplaceAll(game.DisplayName, "&", "_")
-
-	var matched shared.Item
-
-	// naive search first
-	for _, art := range artList {
-		if strings.Contains(strings.ToLower(art.Filename), strings.ToLower(cleanedName)) {
-			matched = art
-			break
-		}
-	}
-
-	if matched.Filename != "" {
-		lastSavedArtPath, err := client.DownloadArt(section.HostSubdirectory, artDirectory, matched.Filename, game.Filename)
-		if err != nil {
-			return ""
-		}
-
-		src, err := imaging.Open(lastSavedArtPath)
-		if err != nil {
-			logger.Error("Unable to open last saved art", "error", err)
-			return ""
-		}
-
-		dst := imaging.Resize(src, 500, 0, imaging.Lanczos)
-
-		err = imaging.Save(dst, lastSavedArtPath)
-		if err != nil {
-			logger.Error("Unable to save resized last saved art", "error", err)
-			return ""
-		}
-
-		return lastSavedArtPath
-	}
-
-	return ""
+	return gameFolderPath, err
 }
 
 func MapTagsToDirectories(items shared.Items) map[string]string {
@@ -612,7 +529,7 @@ func MapTagsToDirectories(items shared.Items) map[string]string {
 		if entry.IsDirectory {
 			tag := strings.ReplaceAll(entry.Tag, "(", "")
 			tag = strings.ReplaceAll(tag, ")", "")
-			path := filepath.Join(common.RomDirectory, entry.Filename)
+			path := filepath.Join(ActiveMediaLayout().RomDirectory, entry.Filename)
 			mapping[tag] = path
 		}
 	}
@@ -645,6 +562,8 @@ func DeleteCache() error {
 	if err != nil {
 		return err
 	}
+	thumbnailIndexes.Clear()
+	catalogFetchTimes.Clear()
 	err = os.RemoveAll(filepath.Join(cwd, ".cache"))
 	if err != nil {
 		logger.Error("Unable to delete cache", "error", err)
@@ -656,15 +575,20 @@ func DeleteCache() error {
 }
 
 func LoadArcadeMapping(filePath string) (map[string]string, error) {
-	mapping := make(map[string]string)
-
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	return ParseArcadeMapping(file)
+}
+
+// ParseArcadeMapping reads tab separated lines of ROM filename and display name.
+func ParseArcadeMapping(r io.Reader) (map[string]string, error) {
+	mapping := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 
@@ -687,13 +611,16 @@ func LoadArcadeMapping(filePath string) (map[string]string, error) {
 	return mapping, nil
 }
 
-func AllPlatformsHaveLocalFolders(config *models.Config) []string {
-	var missingPlatforms []string
+// PlatformsWithoutLocalFolders returns one platform per name for every platform that has no local folder.
+func PlatformsWithoutLocalFolders(config *models.Config) models.Platforms {
+	var missingPlatforms models.Platforms
 
 	for _, h := range config.Hosts {
 		for _, p := range h.Platforms {
-			if p.LocalDirectory == "" && !slices.Contains(missingPlatforms, p.Name) {
-				missingPlatforms = append(missingPlatforms, p.Name)
+			if p.LocalDirectory == "" && !slices.ContainsFunc(missingPlatforms, func(m models.Platform) bool {
+				return m.Name == p.Name
+			}) {
+				missingPlatforms = append(missingPlatforms, p)
 			}
 		}
 	}
@@ -701,10 +628,117 @@ func AllPlatformsHaveLocalFolders(config *models.Config) []string {
 	return missingPlatforms
 }
 
-func IsConnectedToInternet() bool {
-	timeout := 5 * time.Second
-	_, err := net.DialTimeout("tcp", "8.8.8.8:53", timeout)
-	return err == nil
+// RomFolderName is the NextUI folder name for a platform, e.g. Game Boy (GB).
+func RomFolderName(platform models.Platform) string {
+	name := strings.NewReplacer("/", "-", "\\", "-", ":", " -").Replace(strings.TrimSpace(platform.Name))
+
+	if platform.SystemTag == "" {
+		return name
+	}
+
+	return fmt.Sprintf("%s (%s)", name, strings.Trim(platform.SystemTag, "()"))
+}
+
+func CreateRomFolder(platform models.Platform) (string, error) {
+	folder := filepath.Join(GetRomDirectory(), RomFolderName(platform))
+
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return "", fmt.Errorf("unable to create ROM folder: %w", err)
+	}
+
+	return folder, nil
+}
+
+// SetLocalDirectory points every platform with the given name at a folder.
+func SetLocalDirectory(config *models.Config, platformName string, directory string) {
+	for hostIdx, host := range config.Hosts {
+		for platformIdx, platform := range host.Platforms {
+			if platform.Name == platformName {
+				config.Hosts[hostIdx].Platforms[platformIdx].LocalDirectory = directory
+			}
+		}
+	}
+}
+
+// RemovePlatforms drops platforms by name from the config. Nothing is saved, so they are back on the next launch.
+func RemovePlatforms(config *models.Config, platformNames []string) {
+	for hostIdx, host := range config.Hosts {
+		config.Hosts[hostIdx].Platforms = slices.DeleteFunc(slices.Clone(host.Platforms), func(p models.Platform) bool {
+			return slices.Contains(platformNames, p.Name)
+		})
+	}
+}
+
+// SaveLocalDirectories writes local_directory for the given platforms to the config file without saving anything
+// else that was worked out at startup.
+func SaveLocalDirectories(session *models.Config, directories map[string]string) error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	config.ArtDownloadType = session.ArtDownloadType
+
+	for platformName, directory := range directories {
+		SetLocalDirectory(config, platformName, directory)
+	}
+
+	return SaveConfig(config)
+}
+
+// SaveSettings writes the options from the Settings screen to the config file. The file is read again first, so
+// platforms skipped for this session and anything else worked out at startup are not saved.
+func SaveSettings(session *models.Config) error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	config.DownloadArt = session.DownloadArt
+	config.ArtDownloadType = session.ArtDownloadType
+	config.UnzipDownloads = session.UnzipDownloads
+	config.GroupBinCue = session.GroupBinCue
+	config.GroupMultiDisc = session.GroupMultiDisc
+	config.LogLevel = session.LogLevel
+
+	return SaveConfig(config)
+}
+
+// SavePlatformSettings applies a change to one platform in the running config and in the config file, without saving
+// anything else that was worked out at startup. The platform is returned with the change and its host refreshed.
+func SavePlatformSettings(session *models.Config, platform models.Platform, update func(*models.Platform)) (models.Platform, error) {
+	if host := updatePlatform(session, platform, update); host != nil {
+		platform.Host = *host
+	}
+	update(&platform)
+
+	config, err := LoadConfig()
+	if err != nil {
+		return platform, err
+	}
+
+	config.ArtDownloadType = session.ArtDownloadType
+
+	updatePlatform(config, platform, update)
+
+	return platform, SaveConfig(config)
+}
+
+func updatePlatform(config *models.Config, platform models.Platform, update func(*models.Platform)) *models.Host {
+	for hostIdx, host := range config.Hosts {
+		if host.DisplayName != platform.Host.DisplayName {
+			continue
+		}
+
+		for platformIdx, p := range host.Platforms {
+			if p.Name == platform.Name {
+				update(&config.Hosts[hostIdx].Platforms[platformIdx])
+				return &config.Hosts[hostIdx]
+			}
+		}
+	}
+
+	return nil
 }
 
 func GetLocalIP() (string, error) {
@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"mortar/clients"
+	"mortar/models"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+)
+
+// IdentifyLocalRoms hashes the local ROMs of a platform and proposes canonical names from a DAT and / or RomM.
+// Either source may be nil. Grouped game folders are left alone as their CUE and M3U files reference the old names.
+func IdentifyLocalRoms(platform models.Platform, dat *models.Dat, rommRoms []clients.RomMRom) ([]models.RenameProposal, error) {
+	logger := gaba.GetLoggerInstance()
+
+	roms, err := ScanLocalRoms(ResolveLocalDirectory(platform))
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan local ROMs: %w", err)
+	}
+
+	var byCRC map[string][]datRomRef
+	if dat != nil {
+		byCRC = indexDatByCRC(dat)
+	}
+
+	rommByHash := indexRomMByHash(rommRoms)
+
+	var proposals []models.RenameProposal
+
+	for _, rom := range roms {
+		if rom.IsDirectory {
+			continue
+		}
+
+		hashes, err := HashLocalRom(rom, true)
+		if err != nil {
+			logger.Error("Unable to hash local ROM", "path", rom.Path, "error", err)
+			continue
+		}
+
+		canonical, source := "", ""
+
+		if byCRC != nil {
+			if game, _ := matchDatGame(hashes, byCRC); game != nil {
+				canonical, source = game.Name, "DAT"
+			}
+		}
+
+		if canonical == "" && len(rommByHash) > 0 {
+			if strings.ToLower(filepath.Ext(rom.Path)) == ".zip" {
+				if archiveHashes, err := HashFile(rom.Path); err == nil {
+					hashes = append(hashes, archiveHashes)
+				}
+			}
+
+			for _, h := range hashes {
+				match, ok := findRomMByHash(rommByHash, h)
+				if ok {
+					canonical = strings.TrimSuffix(match.FsName, filepath.Ext(match.FsName))
+					source = "RomM"
+					break
+				}
+			}
+		}
+
+		if canonical == "" || canonical == rom.Name {
+			continue
+		}
+
+		proposals = append(proposals, models.RenameProposal{
+			LocalPath:    rom.Path,
+			CurrentName:  filepath.Base(rom.Path),
+			ProposedName: sanitizeFilename(canonical) + filepath.Ext(rom.Path),
+			Source:       source,
+		})
+	}
+
+	logger.Debug("Identified local ROMs", "platform", platform.Name, "proposals", len(proposals))
+
+	return proposals, nil
+}
+
+// ApplyRename renames a local ROM and moves its art along with it.
+func ApplyRename(platform models.Platform, proposal models.RenameProposal) error {
+	directory := filepath.Dir(proposal.LocalPath)
+	target := filepath.Join(directory, proposal.ProposedName)
+
+	if _, err := os.Stat(target); err == nil {
+		return fmt.Errorf("%s already exists", proposal.ProposedName)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := os.Rename(proposal.LocalPath, target); err != nil {
+		return err
+	}
+
+	return moveArt(platform, proposal.CurrentName, proposal.ProposedName)
+}
+
+func moveArt(platform models.Platform, currentName, proposedName string) error {
+	layout := ActiveMediaLayout()
+	newPath := layout.ArtPath(platform, proposedName)
+	newStem := strings.TrimSuffix(filepath.Base(newPath), filepath.Ext(newPath))
+
+	for _, art := range layout.FindArtFiles(platform, currentName) {
+		target := filepath.Join(filepath.Dir(art), newStem+filepath.Ext(art))
+		if err := os.Rename(art, target); err != nil {
+			return fmt.Errorf("unable to move art %s: %w", filepath.Base(art), err)
+		}
+	}
+
+	return nil
+}
+
+func indexRomMByHash(roms []clients.RomMRom) map[string]clients.RomMRom {
+	index := make(map[string]clients.RomMRom)
+
+	add := func(hash string, rom clients.RomMRom) {
+		if hash != "" {
+			index[strings.ToLower(hash)] = rom
+		}
+	}
+
+	for _, rom := range roms {
+		add(rom.Sha1Hash, rom)
+		add(rom.Md5Hash, rom)
+		add(rom.CrcHash, rom)
+		for _, file := range rom.Files {
+			add(file.Sha1Hash, rom)
+			add(file.Md5Hash, rom)
+			add(file.CrcHash, rom)
+		}
+	}
+
+	return index
+}
+
+func findRomMByHash(index map[string]clients.RomMRom, hashes FileHashes) (clients.RomMRom, bool) {
+	for _, hash := range []string{hashes.SHA1, hashes.MD5, hashes.CRC} {
+		if hash == "" {
+			continue
+		}
+		if rom, ok := index[hash]; ok {
+			return rom, true
+		}
+	}
+
+	return clients.RomMRom{}, false
+}
+
+func sanitizeFilename(name string) string {
+	return strings.NewReplacer("/", "-", "\\", "-", ":", " -").Replace(name)
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"golang.org/x/text/unicode/norm"
+)
+
+// LetterEntry is where games starting with a letter begin in a list sorted by name. Names that don't start with a
+// letter are grouped under #.
+type LetterEntry struct {
+	Letter string
+	Index  int
+	Count  int
+}
+
+// GameLetter is the letter a name is indexed under, ignoring accents.
+func GameLetter(name string) string {
+	for _, r := range norm.NFD.String(name) {
+		if unicode.Is(unicode.Mn, r) || unicode.IsSpace(r) {
+			continue
+		}
+
+		if r = unicode.ToUpper(r); r >= 'A' && r <= 'Z' {
+			return string(r)
+		}
+
+		return "#"
+	}
+
+	return "#"
+}
+
+// LetterIndex finds where each letter starts in a list sorted by name. A host's own index, like RomM's char_index, is
+// used when every letter in it starts exactly where it says in this list. It usually doesn't once the list has been
+// filtered or named differently from the host, and the index is worked out from the list instead.
+func LetterIndex(items shared.Items, hostIndex map[string]int) []LetterEntry {
+	var entries []LetterEntry
+
+	if entries = validLetterIndex(items, hostIndex); entries == nil {
+		for idx, item := range items {
+			letter := GameLetter(item.DisplayName)
+			if slices.ContainsFunc(entries, func(e LetterEntry) bool { return e.Letter == letter }) {
+				continue
+			}
+			entries = append(entries, LetterEntry{Letter: letter, Index: idx})
+		}
+	}
+
+	for idx := range entries {
+		next := len(items)
+		if idx+1 < len(entries) {
+			next = entries[idx+1].Index
+		}
+		entries[idx].Count = next - entries[idx].Index
+	}
+
+	return entries
+}
+
+func validLetterIndex(items shared.Items, hostIndex map[string]int) []LetterEntry {
+	if len(hostIndex) == 0 {
+		return nil
+	}
+
+	letters := make(map[string]bool)
+	for _, item := range items {
+		letters[GameLetter(item.DisplayName)] = true
+	}
+
+	var entries []LetterEntry
+	for key, idx := range hostIndex {
+		letter := strings.ToUpper(key)
+		if letter != "#" && GameLetter(letter) != letter {
+			letter = "#"
+		}
+
+		if idx < 0 || idx >= len(items) || GameLetter(items[idx].DisplayName) != letter ||
+			idx > 0 && GameLetter(items[idx-1].DisplayName) == letter {
+			return nil
+		}
+
+		entries = append(entries, LetterEntry{Letter: letter, Index: idx})
+	}
+
+	if len(entries) != len(letters) {
+		return nil
+	}
+
+	slices.SortFunc(entries, func(a, b LetterEntry) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+
+	return entries
+}
@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"mortar/models"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	"github.com/UncleJunVIP/nextui-pak-shared-functions/common"
+)
+
+const (
+	MediaLayoutNextUI = "NEXTUI"
+	MediaLayoutMinUI  = "MINUI"
+	MediaLayoutMuOS   = "MUOS"
+	MediaLayoutKnulli = "KNULLI"
+)
+
+// muOS mounts the first card at /mnt/mmc and a second one at /mnt/sdcard. ROMs and the catalogue are kept on the same
+// card so art lands next to the games it belongs to.
+const (
+	muOSPrimaryMount   = "/mnt/mmc"
+	muOSSecondaryMount = "/mnt/sdcard"
+)
+
+// MediaLayout describes where a frontend keeps ROMs and art and what the art should look like.
+type MediaLayout struct {
+	Name         string
+	RomDirectory string
+	Processing   models.ArtProcessing
+
+	// ReservedDirectories are folders inside a ROM directory that hold media rather than games.
+	ReservedDirectories []string
+
+	artDirectory func(platform models.Platform) string
+	artStem      func(romFilename string, isDirectory bool) string
+}
+
+var mediaLayouts map[string]MediaLayout
+
+var activeMediaLayout MediaLayout
+
+// The layouts are built in init as their art directories depend on the active layout's ROM directory.
+func init() {
+	mediaLayouts = map[string]MediaLayout{
+		MediaLayoutNextUI: {
+			Name:         MediaLayoutNextUI,
+			RomDirectory: common.RomDirectory,
+			Processing:   models.ArtProcessing{Width: 500, Mode: ArtModeFit, Format: ArtFormatPNG},
+			artDirectory: func(platform models.Platform) string {
+				return filepath.Join(ResolveLocalDirectory(platform), ".media")
+			},
+			artStem: romStem,
+		},
+		MediaLayoutMinUI: {
+			Name:         MediaLayoutMinUI,
+			RomDirectory: common.RomDirectory,
+			Processing:   models.ArtProcessing{Width: 320, Mode: ArtModeFit, Format: ArtFormatPNG},
+			artDirectory: func(platform models.Platform) string {
+				return filepath.Join(ResolveLocalDirectory(platform), ".res")
+			},
+			// MinUI keeps the ROM extension, e.g. .res/Tetris (World).gb.png
+			artStem: func(romFilename string, isDirectory bool) string {
+				return romFilename
+			},
+		},
+		MediaLayoutMuOS: {
+			Name:         MediaLayoutMuOS,
+			RomDirectory: filepath.Join(muOSMountRoot(), "ROMS"),
+			Processing:   models.ArtProcessing{Width: 320, Mode: ArtModeFit, Format: ArtFormatPNG},
+			artDirectory: func(platform models.Platform) string {
+				system := platform.MediaSystem
+				if system == "" {
+					system = platform.Name
+				}
+				return filepath.Join(muOSMountRoot(), "MUOS", "info", "catalogue", system, "box")
+			},
+			artStem: romStem,
+		},
+		MediaLayoutKnulli: {
+			Name:                MediaLayoutKnulli,
+			RomDirectory:        "/userdata/roms",
+			Processing:          models.ArtProcessing{Width: 500, Mode: ArtModeFit, Format: ArtFormatPNG},
+			ReservedDirectories: []string{"images", "videos", "manuals"},
+			artDirectory: func(platform models.Platform) string {
+				return filepath.Join(ResolveLocalDirectory(platform), "images")
+			},
+			artStem: func(romFilename string, isDirectory bool) string {
+				return romStem(romFilename, isDirectory) + "-thumb"
+			},
+		},
+	}
+
+	activeMediaLayout = mediaLayouts[MediaLayoutNextUI]
+}
+
+// SetMediaLayout selects the layout by name. An empty name detects the layout from the running firmware.
+func SetMediaLayout(name string) {
+	logger := gaba.GetLoggerInstance()
+
+	if name == "" {
+		name = DetectMediaLayout()
+	}
+
+	layout, ok := mediaLayouts[strings.ToUpper(name)]
+	if !ok {
+		logger.Error("Unknown media layout... defaulting to NextUI", "media_layout", name)
+		layout = mediaLayouts[MediaLayoutNextUI]
+	}
+
+	logger.Debug("Using media layout", "media_layout", layout.Name)
+
+	activeMediaLayout = layout
+}
+
+func ActiveMediaLayout() MediaLayout {
+	return activeMediaLayout
+}
+
+// DetectMediaLayout guesses the layout from files each firmware ships with. MinUI can't be told apart from NextUI
+// this way and has to be configured.
+func DetectMediaLayout() string {
+	switch {
+	case pathExists("/opt/muos"):
+		return MediaLayoutMuOS
+	case pathExists("/usr/share/batocera"), pathExists("/userdata/system"):
+		return MediaLayoutKnulli
+	default:
+		return MediaLayoutNextUI
+	}
+}
+
+func (l MediaLayout) ArtDirectory(platform models.Platform) string {
+	return l.artDirectory(platform)
+}
+
+// ArtPath is where art for a ROM is saved. romFilename may also name a grouped game folder.
+func (l MediaLayout) ArtPath(platform models.Platform, romFilename string) string {
+	return filepath.Join(l.ArtDirectory(platform), l.stem(platform, romFilename)+ArtExtension(ActiveArtProcessing()))
+}
+
+// FindArtFiles returns existing art for a ROM in any image format.
+func (l MediaLayout) FindArtFiles(platform models.Platform, romFilename string) []string {
+	directory := l.ArtDirectory(platform)
+	stem := l.stem(platform, romFilename)
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil
+	}
+
+	var art []string
+	for _, entry := range entries {
+		if !entry.IsDir() && trimExtension(entry.Name()) == stem {
+			art = append(art, filepath.Join(directory, entry.Name()))
+		}
+	}
+
+	return art
+}
+
+func (l MediaLayout) IsReservedDirectory(name string) bool {
+	return slices.Contains(l.ReservedDirectories, name)
+}
+
+func (l MediaLayout) stem(platform models.Platform, romFilename string) string {
+	info, err := os.Stat(filepath.Join(ResolveLocalDirectory(platform), romFilename))
+	return l.artStem(romFilename, err == nil && info.IsDir())
+}
+
+// romStem drops the extension of ROM files. Folder names are kept whole as they may contain dots.
+func romStem(romFilename string, isDirectory bool) string {
+	if isDirectory {
+		return romFilename
+	}
+	return trimExtension(romFilename)
+}
+
+func trimExtension(filename string) string {
+	return strings.TrimSuffix(filename, filepath.Ext(filename))
+}
+
+// muOSMountRoot is the second card when it has a ROMS folder and the first card otherwise.
+func muOSMountRoot() string {
+	if pathExists(filepath.Join(muOSSecondaryMount, "ROMS")) {
+		return muOSSecondaryMount
+	}
+	return muOSPrimaryMount
+}
+
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
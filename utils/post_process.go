@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"mortar/models"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+)
+
+// Files that come along in ROM zips or sit in ROM folders but aren't something a frontend launches.
+var extraFileExtensions = []string{".txt", ".nfo", ".diz", ".pdf", ".htm", ".html", ".url", ".xml", ".jpg", ".jpeg", ".png",
+	".webp", ".bmp", ".gif"}
+
+// PostProcessDownload unzips or groups a downloaded game as configured. It returns a copy of the game for each thing
+// the frontend ends up launching, with Filename set to the ROM file or game folder in the platform directory.
+func PostProcessDownload(platform models.Platform, game shared.Item, config *models.Config) shared.Items {
+	isMultiDisc := IsMultiDisc(platform, game)
+
+	var paths []string
+
+	if filepath.Ext(game.Filename) == ".zip" && !platform.IsArcade {
+		isBinCue := HasBinCue(platform, game)
+
+		if isMultiDisc && config.GroupMultiDisc {
+			if folder, err := GroupMultiDisk(platform, game); err == nil {
+				paths = append(paths, folder)
+			}
+		} else if config.GroupBinCue && isBinCue {
+			paths = GroupBinCue(platform, game)
+		} else if config.UnzipDownloads {
+			extracted, err := UnzipGame(platform, game)
+			if err == nil {
+				paths = extracted
+			}
+		}
+	} else if config.GroupMultiDisc && isMultiDisc {
+		if folder, err := GroupMultiDisk(platform, game); err == nil {
+			paths = append(paths, folder)
+		}
+	}
+
+	return launchableItems(platform, game, paths)
+}
+
+func launchableItems(platform models.Platform, game shared.Item, paths []string) shared.Items {
+	romDirectory := ResolveLocalDirectory(platform)
+
+	var items shared.Items
+	var seen []string
+
+	for _, path := range paths {
+		if slices.Contains(extraFileExtensions, strings.ToLower(filepath.Ext(path))) {
+			continue
+		}
+
+		relative, err := filepath.Rel(romDirectory, path)
+		if err != nil || strings.HasPrefix(relative, "..") {
+			continue
+		}
+
+		// Files unzipped into a sub folder are launched through that folder.
+		name := strings.Split(relative, string(os.PathSeparator))[0]
+		if slices.Contains(seen, name) {
+			continue
+		}
+		seen = append(seen, name)
+
+		item := game
+		item.Filename = name
+		items = append(items, item)
+	}
+
+	if len(items) == 0 {
+		return shared.Items{game}
+	}
+
+	// A playlist or cue sheet is what gets launched, the tracks it points at are not games of their own.
+	for _, launcher := range []string{".m3u", ".cue"} {
+		isLauncher := func(item shared.Item) bool {
+			return strings.ToLower(filepath.Ext(item.Filename)) == launcher
+		}
+
+		if slices.ContainsFunc(items, isLauncher) {
+			return slices.DeleteFunc(items, func(item shared.Item) bool { return !isLauncher(item) })
+		}
+	}
+
+	return items
+}
+
+// UniqueArtGames keeps one game per multi-disc set so art is only looked up once for it.
+func UniqueArtGames(games shared.Items) shared.Items {
+	seenBaseNames := make(map[string]bool)
+
+	// Create a pruned list for art downloads that only includes one instance of each multi-disk game
+	prunedGamesForArt := make([]shared.Item, 0, len(games))
+
+	for _, game := range games {
+		// Get base name by trimming at "(Disk" or "(Disc"
+		baseName := game.DisplayName
+		diskIndex := strings.Index(baseName, "(Disk")
+		discIndex := strings.Index(baseName, "(Disc")
+
+		trimIndex := -1
+		if diskIndex != -1 && discIndex != -1 {
+			trimIndex = min(diskIndex, discIndex)
+		} else if diskIndex != -1 {
+			trimIndex = diskIndex
+		} else if discIndex != -1 {
+			trimIndex = discIndex
+		}
+
+		if trimIndex != -1 {
+			baseName = strings.TrimSpace(baseName[:trimIndex])
+		} else {
+			baseName = game.Filename
+		}
+
+		// If we haven't seen this base name before, add it to the pruned list
+		if !seenBaseNames[baseName] {
+			seenBaseNames[baseName] = true
+			prunedGamesForArt = append(prunedGamesForArt, game)
+		}
+	}
+
+	return prunedGamesForArt
+}
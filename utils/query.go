@@ -0,0 +1,448 @@
+package utils
+
+import (
+	"fmt"
+	"mortar/models"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+)
+
+// Query is a game list search. Free text is matched fuzzily while quoted phrases and key:value filters narrow the list
+// down, see ParseQuery.
+type Query struct {
+	text    SearchQuery
+	phrases []string
+	filters []queryFilter
+}
+
+type queryFilter struct {
+	key    string
+	op     string
+	value  string
+	number int64
+	negate bool
+}
+
+// QueryFacts is what a query needs to know about a game beyond its item.
+type QueryFacts struct {
+	Metadata  models.GameMetadata
+	Installed bool
+}
+
+var queryKeys = []string{"region", "lang", "size", "year", "genre", "installed"}
+
+var queryOperators = []string{"<=", ">=", "<", ">", "=", ":"}
+
+// Languages assumed for single-region releases that don't list theirs.
+var regionLanguages = map[string]string{
+	"usa": "en", "uk": "en", "australia": "en", "canada": "en", "world": "en",
+	"japan": "ja", "korea": "ko", "china": "zh", "germany": "de", "france": "fr",
+	"spain": "es", "italy": "it", "netherlands": "nl", "sweden": "sv", "brazil": "pt",
+}
+
+var regionAliases = map[string]string{
+	"us": "usa", "eu": "europe", "jp": "japan", "jpn": "japan", "kr": "korea", "wor": "world",
+}
+
+// ParseQuery reads a search like `zelda region:usa -beta size<8mb "link to the past"`.
+//
+//   - region:usa and lang:en match the No-Intro tags in the filename
+//   - size<64mb compares the file size, <, <=, >, >= are supported
+//   - year:1995 matches the release year, and takes the same comparisons as size
+//   - genre:rpg matches the genres RomM has for the game
+//   - installed:yes or installed:no checks the platform's ROM folder
+//   - "quoted phrases" have to appear as written
+//   - a leading - negates a filter, or excludes games with the word or tag, like -beta
+func ParseQuery(raw string) (Query, error) {
+	parts, err := splitQuery(raw)
+	if err != nil {
+		return Query{}, err
+	}
+
+	var q Query
+	var text []string
+
+	for _, part := range parts {
+		if part.quoted {
+			if phrase := strings.Join(SearchTokens(part.value), " "); phrase != "" {
+				q.phrases = append(q.phrases, phrase)
+			}
+			continue
+		}
+
+		negate := false
+		value := part.value
+		if len(value) > 1 && value[0] == '-' {
+			negate = true
+			value = value[1:]
+		}
+
+		filter, ok, err := parseQueryFilter(value)
+		if err != nil {
+			return Query{}, err
+		}
+
+		if ok {
+			filter.negate = negate
+			q.filters = append(q.filters, filter)
+			continue
+		}
+
+		if negate {
+			words := SearchTokens(value)
+			if len(words) == 0 {
+				return Query{}, fmt.Errorf("nothing to exclude after \"-\"")
+			}
+
+			for _, word := range words {
+				q.filters = append(q.filters, queryFilter{value: word, negate: true})
+			}
+			continue
+		}
+
+		text = append(text, value)
+	}
+
+	q.text = NewSearchQuery(strings.Join(text, " "))
+
+	return q, nil
+}
+
+type queryPart struct {
+	value  string
+	quoted bool
+}
+
+func splitQuery(raw string) ([]queryPart, error) {
+	var parts []queryPart
+	var current strings.Builder
+	quoted := false
+
+	flush := func() {
+		if current.Len() > 0 || quoted {
+			parts = append(parts, queryPart{value: current.String(), quoted: quoted})
+		}
+		current.Reset()
+	}
+
+	for _, r := range raw {
+		switch {
+		case r == '"' || r == '“' || r == '”':
+			flush()
+			quoted = !quoted
+		case unicode.IsSpace(r) && !quoted:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("a quote is missing its closing \"")
+	}
+
+	flush()
+
+	return parts, nil
+}
+
+// parseQueryFilter reads key:value and key<value. Anything that doesn't start with a known key is free text, unless
+// it looks like a filter with a key that doesn't exist.
+func parseQueryFilter(part string) (queryFilter, bool, error) {
+	idx := strings.IndexAny(part, ":<>=")
+	if idx <= 0 {
+		return queryFilter{}, false, nil
+	}
+
+	key := strings.ToLower(part[:idx])
+	if !slices.Contains(queryKeys, key) {
+		if idx == len(part)-1 || !isQueryKey(key) {
+			return queryFilter{}, false, nil
+		}
+		return queryFilter{}, false, fmt.Errorf("unknown filter \"%s\", use one of %s", key, strings.Join(queryKeys, ", "))
+	}
+
+	rest := part[idx:]
+	var op string
+	for _, candidate := range queryOperators {
+		if strings.HasPrefix(rest, candidate) {
+			op = candidate
+			break
+		}
+	}
+
+	value := strings.ToLower(strings.TrimSpace(rest[len(op):]))
+	if value == "" {
+		return queryFilter{}, false, fmt.Errorf("%s needs a value, like %s", key, queryExample(key))
+	}
+
+	filter := queryFilter{key: key, op: op, value: value}
+
+	switch key {
+	case "size":
+		if op == ":" || op == "=" {
+			return queryFilter{}, false, fmt.Errorf("size needs < or >, like %s", queryExample(key))
+		}
+
+		size, ok := ParseFileSize(value)
+		if !ok {
+			return queryFilter{}, false, fmt.Errorf("\"%s\" is not a size, like %s", value, queryExample(key))
+		}
+		filter.number = size
+	case "year":
+		year, err := strconv.Atoi(value)
+		if err != nil || year < 1000 || year > 9999 {
+			return queryFilter{}, false, fmt.Errorf("\"%s\" is not a year, like %s", value, queryExample(key))
+		}
+		filter.number = int64(year)
+	case "installed":
+		switch value {
+		case "yes", "y", "true":
+			filter.value = "yes"
+		case "no", "n", "false":
+			filter.value = "no"
+		default:
+			return queryFilter{}, false, fmt.Errorf("installed is yes or no, not \"%s\"", value)
+		}
+		fallthrough
+	default:
+		if op != ":" && op != "=" {
+			return queryFilter{}, false, fmt.Errorf("%s can't be compared with %s, like %s", key, op, queryExample(key))
+		}
+	}
+
+	if alias, ok := regionAliases[filter.value]; ok && key == "region" {
+		filter.value = alias
+	}
+
+	return filter, true, nil
+}
+
+func isQueryKey(key string) bool {
+	for _, r := range key {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func queryExample(key string) string {
+	switch key {
+	case "region":
+		return "region:usa"
+	case "lang":
+		return "lang:en"
+	case "size":
+		return "size<64mb"
+	case "year":
+		return "year:1995"
+	case "genre":
+		return "genre:rpg"
+	default:
+		return "installed:no"
+	}
+}
+
+func (q Query) IsEmpty() bool {
+	return q.text.IsEmpty() && len(q.phrases) == 0 && len(q.filters) == 0
+}
+
+// IsRanked reports whether results are ordered by relevance rather than kept in list order.
+func (q Query) IsRanked() bool {
+	return !q.text.IsEmpty()
+}
+
+// Text is the free text and phrases, what a host's own search can be given.
+func (q Query) Text() string {
+	return strings.TrimSpace(strings.Join(append(slices.Clone(q.text.tokens), q.phrases...), " "))
+}
+
+// FiltersOnly drops the free text and phrases, keeping the key:value filters and exclusions.
+func (q Query) FiltersOnly() Query {
+	return Query{filters: q.filters}
+}
+
+// NeedsInstalled reports whether the query checks the ROM folder, which is only read when it does.
+func (q Query) NeedsInstalled() bool {
+	return slices.ContainsFunc(q.filters, func(f queryFilter) bool { return f.key == "installed" })
+}
+
+// NeedsMetadata reports whether the query filters on something only RomM knows.
+func (q Query) NeedsMetadata() bool {
+	return slices.ContainsFunc(q.filters, func(f queryFilter) bool { return f.key == "genre" })
+}
+
+// Apply keeps the items that pass every filter, ranked by the free text when there is any.
+func (q Query) Apply(items shared.Items, facts func(shared.Item) QueryFacts) shared.Items {
+	if facts == nil {
+		facts = func(shared.Item) QueryFacts { return QueryFacts{} }
+	}
+
+	var matched shared.Items
+	for _, item := range items {
+		if q.matches(item, facts(item)) {
+			matched = append(matched, item)
+		}
+	}
+
+	if q.text.IsEmpty() {
+		return matched
+	}
+
+	return rankItems(q.text, matched, func(item shared.Item) []string {
+		return facts(item).Metadata.AlternativeNames
+	})
+}
+
+func (q Query) matches(item shared.Item, facts QueryFacts) bool {
+	var names []string
+	if len(q.phrases) > 0 {
+		names = append([]string{item.DisplayName}, facts.Metadata.AlternativeNames...)
+	}
+
+	for _, phrase := range q.phrases {
+		if !slices.ContainsFunc(names, func(name string) bool {
+			return strings.Contains(" "+strings.Join(SearchTokens(name), " ")+" ", " "+phrase+" ")
+		}) {
+			return false
+		}
+	}
+
+	var title parsedTitle
+	if len(q.filters) > 0 {
+		title = parseTitle(item.Filename)
+	}
+
+	for _, filter := range q.filters {
+		if filter.matches(item, title, facts) == filter.negate {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f queryFilter) matches(item shared.Item, title parsedTitle, facts QueryFacts) bool {
+	switch f.key {
+	case "region":
+		return slices.Contains(title.tags, f.value)
+	case "lang":
+		return slices.Contains(romLanguages(title.tags), f.value)
+	case "size":
+		size, ok := ParseFileSize(item.FileSize)
+		return ok && compareQueryNumber(size, f.op, f.number)
+	case "year":
+		year := int64(facts.Metadata.ReleaseYear)
+		if year == 0 {
+			year = int64(tagYear(title.tags))
+		}
+		return year != 0 && compareQueryNumber(year, f.op, f.number)
+	case "genre":
+		return slices.ContainsFunc(facts.Metadata.Genres, func(genre string) bool {
+			return strings.Contains(strings.ToLower(genre), f.value)
+		})
+	case "installed":
+		return facts.Installed == (f.value == "yes")
+	default:
+		return slices.Contains(title.tokens, f.value) || slices.ContainsFunc(title.tags, func(tag string) bool {
+			return strings.HasPrefix(tag, f.value)
+		})
+	}
+}
+
+func compareQueryNumber(value int64, op string, target int64) bool {
+	switch op {
+	case "<":
+		return value < target
+	case "<=":
+		return value <= target
+	case ">":
+		return value > target
+	case ">=":
+		return value >= target
+	default:
+		return value == target
+	}
+}
+
+// romLanguages are the languages a No-Intro name lists, or the one its region implies when it lists none.
+func romLanguages(tags []string) []string {
+	var languages []string
+	for _, tag := range tags {
+		if len(tag) == 2 && tag != "pd" && regionLanguages[tag] == "" && isLetters(tag) {
+			languages = append(languages, tag)
+		}
+	}
+
+	if len(languages) > 0 {
+		return languages
+	}
+
+	for _, tag := range tags {
+		if language, ok := regionLanguages[tag]; ok && !slices.Contains(languages, language) {
+			languages = append(languages, language)
+		}
+	}
+
+	return languages
+}
+
+func tagYear(tags []string) int {
+	for _, tag := range tags {
+		if len(tag) == 4 && (strings.HasPrefix(tag, "19") || strings.HasPrefix(tag, "20")) {
+			if year, err := strconv.Atoi(tag); err == nil {
+				return year
+			}
+		}
+	}
+	return 0
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseFileSize reads sizes like 1048576, 64mb, 1.5 GiB or 700K. Units are powers of 1024.
+func ParseFileSize(raw string) (int64, bool) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if raw == "" {
+		return 0, false
+	}
+
+	end := strings.IndexFunc(raw, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.'
+	})
+	if end == -1 {
+		end = len(raw)
+	}
+
+	number, err := strconv.ParseFloat(raw[:end], 64)
+	if err != nil {
+		return 0, false
+	}
+
+	multiplier := float64(1)
+	switch strings.TrimSpace(raw[end:]) {
+	case "", "b", "bytes":
+	case "k", "kb", "kib":
+		multiplier = 1 << 10
+	case "m", "mb", "mib":
+		multiplier = 1 << 20
+	case "g", "gb", "gib":
+		multiplier = 1 << 30
+	default:
+		return 0, false
+	}
+
+	return int64(number * multiplier), true
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"fmt"
+	"mortar/models"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const hostProbeTimeout = 3 * time.Second
+
+// hostReachability holds the last probe result for each host, keyed by display name. A nil error means reachable.
+var hostReachability sync.Map
+
+// ProbeHost dials the host's server and records whether it answered.
+func ProbeHost(host models.Host) error {
+	address, err := hostAddress(host)
+	if err == nil {
+		var conn net.Conn
+		conn, err = net.DialTimeout("tcp", address, hostProbeTimeout)
+		if err == nil {
+			_ = conn.Close()
+		}
+	}
+
+	hostReachability.Store(host.DisplayName, probeResult{err: err})
+
+	return err
+}
+
+// ProbeHosts checks every host at the same time.
+func ProbeHosts(hosts models.Hosts) {
+	var wg sync.WaitGroup
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(host models.Host) {
+			defer wg.Done()
+			_ = ProbeHost(host)
+		}(host)
+	}
+	wg.Wait()
+}
+
+// IsHostReachable reports the last probe result. Hosts that haven't been probed are assumed reachable.
+func IsHostReachable(host models.Host) bool {
+	result, ok := hostReachability.Load(host.DisplayName)
+	if !ok {
+		return true
+	}
+
+	return result.(probeResult).err == nil
+}
+
+// HostProbeError is why the last probe of the host failed, if it did.
+func HostProbeError(host models.Host) error {
+	result, ok := hostReachability.Load(host.DisplayName)
+	if !ok {
+		return nil
+	}
+
+	return result.(probeResult).err
+}
+
+type probeResult struct {
+	err error
+}
+
+func hostAddress(host models.Host) (string, error) {
+	root := strings.TrimSpace(host.RootURI)
+	if root == "" {
+		return "", fmt.Errorf("host has no root_uri")
+	}
+
+	if !strings.Contains(root, "://") {
+		root = "http://" + root
+	}
+
+	u, err := url.Parse(root)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse root_uri: %w", err)
+	}
+
+	port := u.Port()
+	if host.Port != 0 {
+		port = strconv.Itoa(host.Port)
+	}
+
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
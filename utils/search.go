@@ -0,0 +1,262 @@
+package utils
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Articles are ignored in queries so "legend zelda" and "the legend of zelda" find "Legend of Zelda, The".
+var searchStopWords = map[string]bool{
+	"the": true, "a": true, "an": true, "of": true, "and": true,
+}
+
+const (
+	exactTokenScore  = 3
+	prefixTokenScore = 2
+	typoTokenScore   = 1
+)
+
+// SearchQuery is a free text query split into normalized tokens.
+type SearchQuery struct {
+	phrase string
+	tokens []string
+}
+
+func NewSearchQuery(query string) SearchQuery {
+	tokens := SearchTokens(query)
+
+	var significant []string
+	for _, token := range tokens {
+		if !searchStopWords[token] {
+			significant = append(significant, token)
+		}
+	}
+
+	if len(significant) == 0 {
+		significant = tokens
+	}
+
+	return SearchQuery{
+		phrase: strings.Join(tokens, " "),
+		tokens: significant,
+	}
+}
+
+func (q SearchQuery) IsEmpty() bool {
+	return len(q.tokens) == 0
+}
+
+// Score rates how well a name matches. Every query token has to match a word in the name, in any order, exactly,
+// as a prefix or with a small typo. Zero means no match.
+func (q SearchQuery) Score(name string) int {
+	if q.IsEmpty() {
+		return 0
+	}
+
+	words := SearchTokens(name)
+
+	// Joined neighbours let "megaman" find "Mega Man".
+	candidates := slices.Clone(words)
+	for i := 0; i+1 < len(words); i++ {
+		candidates = append(candidates, words[i]+words[i+1])
+	}
+
+	score := 0
+	for _, token := range q.tokens {
+		best := 0
+		for _, word := range candidates {
+			best = max(best, matchToken(token, word))
+			if best == exactTokenScore {
+				break
+			}
+		}
+
+		if best == 0 {
+			return 0
+		}
+
+		score += best
+	}
+
+	normalized := strings.Join(words, " ")
+	if strings.HasPrefix(normalized, q.phrase) {
+		score += 5
+	} else if strings.Contains(normalized, q.phrase) {
+		score += 3
+	}
+
+	return score
+}
+
+// SearchItems returns the items matching the query, best match first. alternativeNames can add names to match
+// an item by, like the ones RomM has on record.
+func SearchItems(query string, items shared.Items, alternativeNames func(shared.Item) []string) shared.Items {
+	q := NewSearchQuery(query)
+	if q.IsEmpty() {
+		return items
+	}
+
+	return rankItems(q, items, alternativeNames)
+}
+
+func rankItems(q SearchQuery, items shared.Items, alternativeNames func(shared.Item) []string) shared.Items {
+	type scoredItem struct {
+		item  shared.Item
+		score int
+	}
+
+	var scored []scoredItem
+	for _, item := range items {
+		score := q.Score(item.DisplayName)
+
+		if alternativeNames != nil {
+			for _, name := range alternativeNames(item) {
+				score = max(score, q.Score(name))
+			}
+		}
+
+		if score > 0 {
+			scored = append(scored, scoredItem{item: item, score: score})
+		}
+	}
+
+	slices.SortStableFunc(scored, func(a, b scoredItem) int {
+		if a.score != b.score {
+			return b.score - a.score
+		}
+		if len(a.item.DisplayName) != len(b.item.DisplayName) {
+			return len(a.item.DisplayName) - len(b.item.DisplayName)
+		}
+		return strings.Compare(strings.ToLower(a.item.DisplayName), strings.ToLower(b.item.DisplayName))
+	})
+
+	results := make(shared.Items, 0, len(scored))
+	for _, s := range scored {
+		results = append(results, s.item)
+	}
+
+	return results
+}
+
+// SearchTokens lower-cases text, strips accents and splits it into words on anything that isn't a letter or digit.
+func SearchTokens(text string) []string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(text) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r == '&':
+			b.WriteString(" and ")
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToLower(r))
+		case r == '\'':
+			// Apostrophes join words, "Link's" is searched as "links".
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	return strings.Fields(b.String())
+}
+
+func matchToken(token, word string) int {
+	if token == word {
+		return exactTokenScore
+	}
+
+	if isNumber(token) || isNumber(word) {
+		return 0
+	}
+
+	if len(token) >= 2 && strings.HasPrefix(word, token) {
+		return prefixTokenScore
+	}
+
+	allowed := 0
+	switch {
+	case len(token) >= 8:
+		allowed = 2
+	case len(token) >= 4:
+		allowed = 1
+	}
+
+	if allowed == 0 {
+		return 0
+	}
+
+	if editDistance(token, word, allowed) <= allowed {
+		return typoTokenScore
+	}
+
+	// A typo in a word that is still being typed, "zeld" or "pokmon" against a longer word.
+	if wordRunes, tokenRunes := []rune(word), []rune(token); len(wordRunes) > len(tokenRunes) &&
+		editDistance(token, string(wordRunes[:len(tokenRunes)]), allowed) <= allowed {
+		return typoTokenScore
+	}
+
+	return 0
+}
+
+// editDistance is the Levenshtein distance with swapped neighbours counting as one edit, giving up once it is over
+// limit.
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if abs(len(ra)-len(rb)) > limit {
+		return limit + 1
+	}
+
+	beforePrevious := make([]int, len(rb)+1)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		rowMin := current[0]
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				current[j] = min(current[j], beforePrevious[j-2]+1)
+			}
+
+			rowMin = min(rowMin, current[j])
+		}
+
+		if rowMin > limit {
+			return limit + 1
+		}
+
+		beforePrevious, previous, current = previous, current, beforePrevious
+	}
+
+	return previous[len(rb)]
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"cmp"
+	"mortar/models"
+	"slices"
+	"strings"
+	"time"
+
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+)
+
+// GameSortKeys are the ways a games list can be sorted, in the order they are offered.
+var GameSortKeys = []string{"name", "size", "modified", "rating", "year"}
+
+var gameSortNames = map[string]string{
+	"name":     "Name",
+	"size":     "Size",
+	"modified": "Date Modified",
+	"rating":   "Rating",
+	"year":     "Release Year",
+}
+
+// Hosts report modification dates in different formats, these are the ones seen so far.
+var lastModifiedLayouts = []string{
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"02-Jan-2006 15:04",
+	"2006-Jan-02 15:04",
+	"2006-01-02",
+}
+
+func GameSortName(key string) string {
+	if name, ok := gameSortNames[key]; ok {
+		return name
+	}
+	return gameSortNames["name"]
+}
+
+// GameSortKeysFor leaves out rating and release year when the platform's host has no metadata to sort them by.
+func GameSortKeysFor(metadata models.GameMetadataIndex) []string {
+	if metadata != nil {
+		return GameSortKeys
+	}
+
+	return slices.DeleteFunc(slices.Clone(GameSortKeys), func(key string) bool {
+		return key == "rating" || key == "year"
+	})
+}
+
+// SortGames orders items in place. Games without a value for the key, like an unknown size, go last either way and
+// ties are broken by name.
+func SortGames(items shared.Items, sort models.GameSort, metadata models.GameMetadataIndex) {
+	byName := func(a, b shared.Item) int {
+		return strings.Compare(strings.ToLower(a.DisplayName), strings.ToLower(b.DisplayName))
+	}
+
+	var value func(shared.Item) (float64, bool)
+
+	switch sort.By {
+	case "size":
+		value = func(item shared.Item) (float64, bool) {
+			size, ok := ParseFileSize(item.FileSize)
+			return float64(size), ok
+		}
+	case "modified":
+		value = func(item shared.Item) (float64, bool) {
+			modified, ok := ParseLastModified(item.LastModified)
+			return float64(modified.Unix()), ok
+		}
+	case "rating":
+		value = func(item shared.Item) (float64, bool) {
+			rating := metadata[item.RomID].Rating
+			return rating, rating > 0
+		}
+	case "year":
+		value = func(item shared.Item) (float64, bool) {
+			year := metadata[item.RomID].ReleaseYear
+			return float64(year), year > 0
+		}
+	default:
+		slices.SortStableFunc(items, func(a, b shared.Item) int {
+			if sort.Descending {
+				return byName(b, a)
+			}
+			return byName(a, b)
+		})
+		return
+	}
+
+	slices.SortStableFunc(items, func(a, b shared.Item) int {
+		va, okA := value(a)
+		vb, okB := value(b)
+
+		switch {
+		case okA != okB:
+			if okA {
+				return -1
+			}
+			return 1
+		case va != vb:
+			if sort.Descending {
+				return cmp.Compare(vb, va)
+			}
+			return cmp.Compare(va, vb)
+		default:
+			return byName(a, b)
+		}
+	})
+}
+
+func ParseLastModified(raw string) (time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range lastModifiedLayouts {
+		if parsed, err := time.Parse(layout, raw); err == nil {
+			return parsed, true
+		}
+	}
+
+	return time.Time{}, false
+}
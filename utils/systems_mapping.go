@@ -0,0 +1,218 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"mortar/clients"
+	"mortar/models"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+	"qlova.tech/sum"
+)
+
+const (
+	systemsMappingFile         = "data/systems-mapping.json"
+	systemsMappingOverrideFile = "systems-mapping.json"
+)
+
+// SystemsMapping maps system tags (FC, SFC, MGBA...) to Libretro system names.
+var SystemsMapping = make(map[string]string)
+
+// LoadSystemsMapping reads the bundled mapping and merges the user's systems-mapping.json from the app directory
+// on top, so custom folder tags can be mapped without waiting for a release.
+func LoadSystemsMapping() error {
+	mapping, err := readSystemsMapping(systemsMappingFile)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(systemsMappingOverrideFile); err == nil {
+		overrides, err := readSystemsMapping(systemsMappingOverrideFile)
+		if err != nil {
+			return err
+		}
+
+		for tag, system := range overrides {
+			if system == "" {
+				delete(mapping, tag)
+				continue
+			}
+			mapping[tag] = system
+		}
+
+		gaba.GetLoggerInstance().Debug("Merged systems mapping overrides", "count", len(overrides))
+	}
+
+	SystemsMapping = mapping
+
+	return nil
+}
+
+func readSystemsMapping(filePath string) (map[string]string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read systems mapping: %w", err)
+	}
+
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("unable to parse systems mapping %s: %w", filePath, err)
+	}
+
+	mapping := make(map[string]string, len(raw))
+	for tag, system := range raw {
+		mapping[strings.ToUpper(strings.TrimSpace(tag))] = strings.TrimSpace(system)
+	}
+
+	return mapping, nil
+}
+
+// LibretroSystemName returns the Libretro system name for a tag such as GBA or (GBA).
+func LibretroSystemName(tag string) (string, bool) {
+	tag = strings.ToUpper(strings.Trim(strings.TrimSpace(tag), "()"))
+	system, ok := SystemsMapping[tag]
+	return system, ok
+}
+
+// LibretroThumbnailDirectory is the thumbnail server folder for a system and art type, e.g.
+// /Nintendo - Game Boy/Named_Boxarts/.
+func LibretroThumbnailDirectory(system string, artType sum.Int[shared.ArtDownloadType]) string {
+	folder := "Named_Boxarts"
+
+	switch artType {
+	case shared.ArtDownloadTypes.TITLE_SCREEN:
+		folder = "Named_Titles"
+	case shared.ArtDownloadTypes.SCREENSHOTS:
+		folder = "Named_Snaps"
+	case shared.ArtDownloadTypes.LOGOS:
+		folder = "Named_Logos"
+	}
+
+	return "/" + system + "/" + folder + "/"
+}
+
+// MatchLocalDirectory finds the ROM folder for a platform's system tag. A folder with the same tag wins, otherwise a
+// folder whose tag maps to the same Libretro system is used, so a GBA platform finds a "Game Boy Advance (MGBA)" folder.
+func MatchLocalDirectory(tag string, romDirectories map[string]string) (string, bool) {
+	tag = strings.ToUpper(strings.Trim(strings.TrimSpace(tag), "()"))
+	if tag == "" {
+		return "", false
+	}
+
+	for folderTag, directory := range romDirectories {
+		if strings.EqualFold(folderTag, tag) {
+			return directory, true
+		}
+	}
+
+	system, ok := LibretroSystemName(tag)
+	if !ok {
+		return "", false
+	}
+
+	var matches []string
+	for folderTag, directory := range romDirectories {
+		if folderSystem, ok := LibretroSystemName(folderTag); ok && folderSystem == system {
+			matches = append(matches, directory)
+		}
+	}
+
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	// Map order is random, the same folder should win on every launch
+	slices.Sort(matches)
+
+	return matches[0], true
+}
+
+// MatchRomMPlatform finds the RomM platform for a system tag by slug first, then by the mapped Libretro system name.
+// RomM names drop the manufacturer, so "Nintendo - Game Boy" matches "Game Boy".
+func MatchRomMPlatform(tag string, platforms []clients.RomMPlatform) (clients.RomMPlatform, bool) {
+	slug := strings.ToLower(strings.Trim(tag, "()"))
+	for _, platform := range platforms {
+		if strings.EqualFold(platform.Slug, slug) || strings.EqualFold(platform.FsSlug, slug) {
+			return platform, true
+		}
+	}
+
+	system, ok := LibretroSystemName(tag)
+	if !ok {
+		return clients.RomMPlatform{}, false
+	}
+
+	var names []string
+	parts := strings.Split(system, " - ")
+	for i := range parts {
+		names = append(names, normalizeSystemName(strings.Join(parts[i:], " ")))
+	}
+
+	for _, name := range names {
+		for _, platform := range platforms {
+			for _, candidate := range []string{platform.Name, platform.DisplayName, platform.CustomName, platform.Slug} {
+				if candidate != "" && normalizeSystemName(candidate) == name {
+					return platform, true
+				}
+			}
+		}
+	}
+
+	return clients.RomMPlatform{}, false
+}
+
+// ResolveRomMPlatformIDs fills in romm_platform_id for RomM platforms that only have a system tag.
+func ResolveRomMPlatformIDs(config *models.Config) {
+	logger := gaba.GetLoggerInstance()
+
+	for hostIdx, host := range config.Hosts {
+		if host.HostType != shared.HostTypes.ROMM || !IsHostReachable(host) {
+			continue
+		}
+
+		var romMPlatforms []clients.RomMPlatform
+		listed := false
+
+		for platformIdx, platform := range host.Platforms {
+			if platform.RomMPlatformID != "" || platform.SystemTag == "" {
+				continue
+			}
+
+			if !listed {
+				listed = true
+
+				client := clients.NewRomMClient(host.RootURI, host.Port, host.Username, host.Password)
+				var err error
+				romMPlatforms, err = client.ListPlatforms()
+				if err != nil {
+					logger.Error("Unable to list RomM platforms", "host", host.DisplayName, "error", err)
+				}
+			}
+
+			match, ok := MatchRomMPlatform(platform.SystemTag, romMPlatforms)
+			if !ok {
+				logger.Debug("No RomM platform matches system tag", "host", host.DisplayName, "tag", platform.SystemTag)
+				continue
+			}
+
+			config.Hosts[hostIdx].Platforms[platformIdx].RomMPlatformID = strconv.Itoa(match.ID)
+			logger.Debug("Matched RomM platform", "tag", platform.SystemTag, "romm_platform", match.Slug, "id", match.ID)
+		}
+	}
+}
+
+func normalizeSystemName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,340 @@
+package utils
+
+import (
+	"archive/zip"
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"mortar/models"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+)
+
+type FileHashes struct {
+	Name string
+	Size int64
+	CRC  string
+	MD5  string
+	SHA1 string
+}
+
+// LocalRom is a launchable entry in a ROM directory: a single file, a zip or a grouped game folder.
+type LocalRom struct {
+	Name        string
+	Path        string
+	IsDirectory bool
+}
+
+type datRomRef struct {
+	game *models.DatGame
+	rom  models.DatRom
+}
+
+func ResolveLocalDirectory(platform models.Platform) string {
+	if IsDev() {
+		return strings.ReplaceAll(platform.LocalDirectory, ActiveMediaLayout().RomDirectory, GetRomDirectory())
+	}
+
+	return platform.LocalDirectory
+}
+
+// InstalledRoms lists what is in a platform's ROM folder by lower-cased name without extension, so a zip on the host
+// still counts as installed once it has been unzipped.
+func InstalledRoms(platform models.Platform) map[string]bool {
+	installed := make(map[string]bool)
+
+	roms, err := ScanLocalRoms(ResolveLocalDirectory(platform))
+	if err != nil {
+		return installed
+	}
+
+	for _, rom := range roms {
+		installed[strings.ToLower(rom.Name)] = true
+	}
+
+	return installed
+}
+
+func IsRomInstalled(installed map[string]bool, item shared.Item) bool {
+	return installed[strings.ToLower(romStem(item.Filename, item.IsDirectory))]
+}
+
+// ScanLocalRoms lists the games in a ROM folder, leaving out hidden and temporary files, media folders and files like
+// readmes, gamelist.xml and images that aren't games.
+func ScanLocalRoms(directory string) ([]LocalRom, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var roms []LocalRom
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") || strings.HasSuffix(entry.Name(), ".tmp") ||
+			(entry.IsDir() && ActiveMediaLayout().IsReservedDirectory(entry.Name())) ||
+			(!entry.IsDir() && slices.Contains(extraFileExtensions, strings.ToLower(filepath.Ext(entry.Name())))) {
+			continue
+		}
+
+		roms = append(roms, LocalRom{
+			Name:        strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())),
+			Path:        filepath.Join(directory, entry.Name()),
+			IsDirectory: entry.IsDir(),
+		})
+
+		if entry.IsDir() {
+			roms[len(roms)-1].Name = entry.Name()
+		}
+	}
+
+	return roms, nil
+}
+
+func HashFile(path string) (FileHashes, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return FileHashes{}, err
+	}
+	defer f.Close()
+
+	hashes, err := hashReader(f)
+	if err != nil {
+		return FileHashes{}, fmt.Errorf("unable to hash %s: %w", path, err)
+	}
+
+	hashes.Name = filepath.Base(path)
+
+	return hashes, nil
+}
+
+func hashReader(r io.Reader) (FileHashes, error) {
+	crcHash := crc32.NewIEEE()
+	md5Hash := md5.New()
+	sha1Hash := sha1.New()
+
+	size, err := io.Copy(io.MultiWriter(crcHash, md5Hash, sha1Hash), r)
+	if err != nil {
+		return FileHashes{}, err
+	}
+
+	return FileHashes{
+		Size: size,
+		CRC:  hex.EncodeToString(crcHash.Sum(nil)),
+		MD5:  hex.EncodeToString(md5Hash.Sum(nil)),
+		SHA1: hex.EncodeToString(sha1Hash.Sum(nil)),
+	}, nil
+}
+
+// HashZipContents uses the CRC32 stored in the zip headers so archives do not need to be decompressed.
+func HashZipContents(path string) ([]FileHashes, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip file: %w", err)
+	}
+	defer reader.Close()
+
+	var hashes []FileHashes
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		hashes = append(hashes, FileHashes{
+			Name: filepath.Base(file.Name),
+			Size: int64(file.UncompressedSize64),
+			CRC:  fmt.Sprintf("%08x", file.CRC32),
+		})
+	}
+
+	return hashes, nil
+}
+
+// HashZipEntries decompresses every file in a zip to compute its CRC32, MD5 and SHA1.
+func HashZipEntries(path string) ([]FileHashes, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip file: %w", err)
+	}
+	defer reader.Close()
+
+	var hashes []FileHashes
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		fileHashes, err := hashReader(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to hash %s in %s: %w", file.Name, path, err)
+		}
+
+		fileHashes.Name = filepath.Base(file.Name)
+		hashes = append(hashes, fileHashes)
+	}
+
+	return hashes, nil
+}
+
+// HashLocalRom hashes every file that makes up a local ROM, looking inside zips and grouped folders.
+// Unless full is set, zip entries only carry the CRC32 stored in the archive.
+func HashLocalRom(rom LocalRom, full bool) ([]FileHashes, error) {
+	if !rom.IsDirectory {
+		if strings.ToLower(filepath.Ext(rom.Path)) == ".zip" {
+			if full {
+				return HashZipEntries(rom.Path)
+			}
+			return HashZipContents(rom.Path)
+		}
+
+		hashes, err := HashFile(rom.Path)
+		if err != nil {
+			return nil, err
+		}
+		return []FileHashes{hashes}, nil
+	}
+
+	entries, err := os.ReadDir(rom.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashes []FileHashes
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || strings.ToLower(filepath.Ext(entry.Name())) == ".m3u" {
+			continue
+		}
+
+		fileHashes, err := HashFile(filepath.Join(rom.Path, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, fileHashes)
+	}
+
+	return hashes, nil
+}
+
+func VerifyPlatform(platform models.Platform, dat *models.Dat) (models.VerificationReport, error) {
+	logger := gaba.GetLoggerInstance()
+
+	var report models.VerificationReport
+
+	roms, err := ScanLocalRoms(ResolveLocalDirectory(platform))
+	if err != nil {
+		return report, fmt.Errorf("unable to scan local ROMs: %w", err)
+	}
+
+	byCRC := indexDatByCRC(dat)
+	byName := make(map[string]*models.DatGame)
+	for i := range dat.Games {
+		byName[strings.ToLower(dat.Games[i].Name)] = &dat.Games[i]
+	}
+
+	found := make(map[string]bool)
+
+	for _, rom := range roms {
+		hashes, err := HashLocalRom(rom, false)
+		if err != nil {
+			logger.Error("Unable to hash local ROM", "path", rom.Path, "error", err)
+			continue
+		}
+
+		matched, badDump := matchDatGame(hashes, byCRC)
+
+		switch {
+		case matched == nil:
+			if game, ok := byName[strings.ToLower(rom.Name)]; ok {
+				found[game.Name] = true
+				report.HashMismatches = append(report.HashMismatches, models.VerifiedRom{LocalPath: rom.Path, Game: *game, ExpectedName: game.Name})
+			} else {
+				report.Unknown = append(report.Unknown, rom.Path)
+			}
+		case badDump:
+			found[matched.Name] = true
+			report.BadDumps = append(report.BadDumps, models.VerifiedRom{LocalPath: rom.Path, Game: *matched, ExpectedName: matched.Name})
+		case !strings.EqualFold(rom.Name, matched.Name):
+			found[matched.Name] = true
+			report.Misnamed = append(report.Misnamed, models.VerifiedRom{LocalPath: rom.Path, Game: *matched, ExpectedName: matched.Name})
+		default:
+			found[matched.Name] = true
+			report.Verified = append(report.Verified, models.VerifiedRom{LocalPath: rom.Path, Game: *matched, ExpectedName: matched.Name})
+		}
+	}
+
+	for _, game := range dat.Games {
+		if !found[game.Name] {
+			report.Missing = append(report.Missing, game)
+		}
+	}
+
+	logger.Debug("Verified platform against DAT",
+		"platform", platform.Name,
+		"dat", dat.Name,
+		"verified", len(report.Verified),
+		"bad_dumps", len(report.BadDumps),
+		"misnamed", len(report.Misnamed),
+		"hash_mismatches", len(report.HashMismatches),
+		"unknown", len(report.Unknown),
+		"missing", len(report.Missing))
+
+	return report, nil
+}
+
+func indexDatByCRC(dat *models.Dat) map[string][]datRomRef {
+	byCRC := make(map[string][]datRomRef)
+	for i := range dat.Games {
+		game := &dat.Games[i]
+		for _, rom := range game.Roms {
+			if rom.CRC != "" {
+				byCRC[rom.CRC] = append(byCRC[rom.CRC], datRomRef{game: game, rom: rom})
+			}
+		}
+	}
+
+	return byCRC
+}
+
+// matchDatGame returns the DAT game that the most hashed files belong to.
+func matchDatGame(hashes []FileHashes, byCRC map[string][]datRomRef) (*models.DatGame, bool) {
+	counts := make(map[*models.DatGame]int)
+	badDumps := make(map[*models.DatGame]bool)
+	var order []*models.DatGame
+
+	for _, h := range hashes {
+		for _, ref := range byCRC[h.CRC] {
+			if ref.rom.Size != 0 && h.Size != 0 && ref.rom.Size != h.Size {
+				continue
+			}
+			if counts[ref.game] == 0 {
+				order = append(order, ref.game)
+			}
+			counts[ref.game]++
+			if ref.rom.IsBadDump() {
+				badDumps[ref.game] = true
+			}
+		}
+	}
+
+	if len(order) == 0 {
+		return nil, false
+	}
+
+	best := slices.MaxFunc(order, func(a, b *models.DatGame) int {
+		return counts[a] - counts[b]
+	})
+
+	return best, badDumps[best]
+}
@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mortar/models"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	gaba "github.com/UncleJunVIP/gabagool/pkg/gabagool"
+	shared "github.com/UncleJunVIP/nextui-pak-shared-functions/models"
+)
+
+// The wishlist lives next to the config rather than in the cache, so emptying the cache doesn't lose it. It is only
+// kept on the device and isn't synced to RomM collections.
+const wishlistFile = "wishlist.json"
+
+var wishlistMutex sync.Mutex
+var wishlist models.Wishlist
+var wishlistLoaded bool
+
+// Wishlist returns the starred games in the order they were added.
+func Wishlist() models.Wishlist {
+	wishlistMutex.Lock()
+	defer wishlistMutex.Unlock()
+
+	loadWishlist()
+
+	return slices.Clone(wishlist)
+}
+
+func IsWishlisted(platform models.Platform, item shared.Item) bool {
+	wishlistMutex.Lock()
+	defer wishlistMutex.Unlock()
+
+	loadWishlist()
+
+	return slices.IndexFunc(wishlist, wishlistMatcher(platform.Host.DisplayName, platform.Name, item)) != -1
+}
+
+// ToggleWishlist stars the games that aren't on the wishlist yet. When all of them already are, they are unstarred
+// instead. It returns whether the games are now on the wishlist.
+func ToggleWishlist(platform models.Platform, items shared.Items) (bool, error) {
+	wishlistMutex.Lock()
+	defer wishlistMutex.Unlock()
+
+	loadWishlist()
+
+	starred := false
+	for _, item := range items {
+		if slices.IndexFunc(wishlist, wishlistMatcher(platform.Host.DisplayName, platform.Name, item)) == -1 {
+			starred = true
+			wishlist = append(wishlist, models.WishlistEntry{
+				Host:     platform.Host.DisplayName,
+				Platform: platform.Name,
+				Item:     item,
+				AddedAt:  time.Now(),
+			})
+		}
+	}
+
+	if !starred {
+		for _, item := range items {
+			wishlist = slices.DeleteFunc(wishlist, wishlistMatcher(platform.Host.DisplayName, platform.Name, item))
+		}
+	}
+
+	return starred, saveWishlist()
+}
+
+// RemoveFromWishlist unstars games, like once they have been downloaded.
+func RemoveFromWishlist(entries models.Wishlist) error {
+	wishlistMutex.Lock()
+	defer wishlistMutex.Unlock()
+
+	loadWishlist()
+
+	for _, entry := range entries {
+		wishlist = slices.DeleteFunc(wishlist, wishlistMatcher(entry.Host, entry.Platform, entry.Item))
+	}
+
+	return saveWishlist()
+}
+
+// wishlistMatcher keys entries by host, platform and filename. RomM IDs aren't used as they change when a RomM
+// library is rescanned.
+func wishlistMatcher(host, platform string, item shared.Item) func(models.WishlistEntry) bool {
+	return func(entry models.WishlistEntry) bool {
+		return entry.Host == host && entry.Platform == platform && entry.Item.Filename == item.Filename
+	}
+}
+
+func loadWishlist() {
+	if wishlistLoaded {
+		return
+	}
+	wishlistLoaded = true
+
+	data, err := os.ReadFile(wishlistFile)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			gaba.GetLoggerInstance().Error("Unable to read wishlist", "error", err)
+		}
+		return
+	}
+
+	if err := json.Unmarshal(data, &wishlist); err != nil {
+		// Kept aside so starring another game doesn't overwrite what might still be recovered by hand.
+		gaba.GetLoggerInstance().Error("Unable to parse wishlist, moving it aside", "error", err)
+		wishlist = nil
+		_ = os.Rename(wishlistFile, wishlistFile+".bak")
+	}
+}
+
+func saveWishlist() error {
+	data, err := json.MarshalIndent(wishlist, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal wishlist: %w", err)
+	}
+
+	if err := os.WriteFile(wishlistFile, data, 0644); err != nil {
+		return fmt.Errorf("unable to write wishlist: %w", err)
+	}
+
+	return nil
+}
@@ -1,11 +1,15 @@
 package web
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"mortar/models"
 	"mortar/utils"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -52,6 +56,68 @@ func start() {
 		return c.JSON(http.StatusOK, config)
 	})
 
+	e.GET("/dats", func(c echo.Context) error {
+		datFiles, err := utils.ListDatFiles()
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, err.Error())
+		}
+		return c.JSON(http.StatusOK, datFiles)
+	})
+
+	e.POST("/dats", func(c echo.Context) error {
+		file, err := c.FormFile("file")
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+
+		src, err := file.Open()
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+		defer src.Close()
+
+		data, err := io.ReadAll(src)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+
+		filename := filepath.Base(file.Filename)
+
+		// Arcade mapping files are tab separated text, everything else has to be a DAT.
+		var name string
+		var games int
+
+		if strings.EqualFold(filepath.Ext(filename), ".txt") {
+			mapping, err := utils.ParseArcadeMapping(bytes.NewReader(data))
+			if err != nil || len(mapping) == 0 {
+				return c.JSON(http.StatusBadRequest, "no sets found in arcade mapping file")
+			}
+			name = strings.TrimSuffix(filename, filepath.Ext(filename))
+			games = len(mapping)
+		} else {
+			dat, err := utils.ParseDat(data)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, err.Error())
+			}
+			name = dat.Name
+			games = len(dat.Games)
+		}
+
+		if err := os.MkdirAll(utils.DatsDirectory, 0755); err != nil {
+			return c.JSON(http.StatusInternalServerError, err.Error())
+		}
+
+		if err := os.WriteFile(filepath.Join(utils.DatsDirectory, filename), data, 0644); err != nil {
+			return c.JSON(http.StatusInternalServerError, err.Error())
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"dat_file": filename,
+			"name":     name,
+			"games":    games,
+		})
+	})
+
 	go func() {
 		if err := e.Start(":1337"); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			e.Logger.Fatal("shutting down the server")